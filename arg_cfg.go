@@ -0,0 +1,298 @@
+// Copyright (C) 2026 Takayuki Sato. All Rights Reserved.
+// This program is free software under MIT License.
+// See the file LICENSE in this distribution for more details.
+
+package cliargs
+
+import (
+	"reflect"
+	"sort"
+	"strconv"
+
+	"github.com/sttk/cliargs/errors"
+)
+
+// ArgCfg is the struct that represents a configuration of command arguments, which are positional
+// arguments not associated with any options.
+// An argument configuration consists of fields: Name, Min, Max, Validator, and Desc.
+//
+// The Name field is the name of the command argument(s), which is used in error messages and help
+// texts, and to retrieve the command argument(s) with Cmd#CmdArg or Cmd#CmdArgs.
+//
+// Min and Max are the minimum and the maximum numbers of command arguments which this
+// configuration takes.
+// If Max is negative, the number of command arguments is unlimited, and this is useful for the
+// variadic tail of command arguments.
+// If Max is zero, the maximum number is same with Min, or 1 if Min is zero too.
+// That is, the zero values of both fields mean one optional command argument.
+//
+// Validator is the field to set a function pointer which validates each command argument.
+// The function takes the Name of this configuration as both of the first and second arguments, so
+// that the validators of the validators package can be used.
+//
+// Desc is the field to set the description of the command argument(s).
+type ArgCfg struct {
+	Name      string
+	Min       int
+	Max       int
+	Validator *func(string, string, string) error
+	Desc      string
+	onParsed  *func([]string) error
+}
+
+func (cfg ArgCfg) countRange() (int, int) {
+	min := cfg.Min
+	if min < 0 {
+		min = 0
+	}
+	max := cfg.Max
+	if max == 0 {
+		max = min
+		if max == 0 {
+			max = 1
+		}
+	} else if max > 0 && max < min {
+		max = min
+	}
+	return min, max
+}
+
+// distributeCmdArgs is the function that assigns command arguments to argument configurations in
+// order.
+// Each configuration takes as many command arguments as possible while leaving the minimum numbers
+// for the following configurations.
+// This function returns the assigned arguments for each configuration, the index of the first
+// configuration which lacks command arguments (or -1), and the command arguments left over.
+func distributeCmdArgs(argCfgs []ArgCfg, args []string) ([][]string, int, []string) {
+	restMins := make([]int, len(argCfgs)+1)
+	for i := len(argCfgs) - 1; i >= 0; i-- {
+		min, _ := argCfgs[i].countRange()
+		restMins[i] = restMins[i+1] + min
+	}
+
+	assigned := make([][]string, len(argCfgs))
+	missingIdx := -1
+	pos := 0
+
+	for i, cfg := range argCfgs {
+		min, max := cfg.countRange()
+		n := len(args) - pos - restMins[i+1]
+		if max >= 0 && n > max {
+			n = max
+		}
+		if n < min {
+			n = min
+		}
+		if pos+n > len(args) {
+			n = len(args) - pos
+			if missingIdx < 0 {
+				missingIdx = i
+			}
+		}
+		assigned[i] = args[pos : pos+n]
+		pos += n
+	}
+
+	return assigned, missingIdx, args[pos:]
+}
+
+func (cmd *Cmd) checkCmdArgs() []error {
+	if len(cmd.ArgCfgs) == 0 {
+		return nil
+	}
+
+	assigned, missingIdx, rest := distributeCmdArgs(cmd.ArgCfgs, cmd.Args)
+
+	var errs []error
+	if missingIdx >= 0 {
+		errs = append(errs, errors.MissingCmdArg{Name: cmd.ArgCfgs[missingIdx].Name})
+	}
+	if len(rest) > 0 {
+		errs = append(errs, errors.TooManyCmdArgs{Args: rest})
+	}
+
+	for i, cfg := range cmd.ArgCfgs {
+		isValid := true
+		if cfg.Validator != nil {
+			for _, arg := range assigned[i] {
+				e := (*cfg.Validator)(cfg.Name, cfg.Name, arg)
+				if e != nil {
+					errs = append(errs, errors.CmdArgIsInvalid{Name: cfg.Name, Arg: arg, Cause: e})
+					isValid = false
+				}
+			}
+		}
+		if isValid && len(assigned[i]) > 0 && cfg.onParsed != nil {
+			e := (*cfg.onParsed)(assigned[i])
+			if e != nil {
+				arg, cause := assigned[i][0], e
+				if ee, ok := e.(errors.OptionArgIsInvalid); ok {
+					arg, cause = ee.OptArg, ee.Cause
+				}
+				errs = append(errs, errors.CmdArgIsInvalid{Name: cfg.Name, Arg: arg, Cause: cause})
+			}
+		}
+	}
+
+	return errs
+}
+
+// CmdArg is the method that returns the command argument assigned to the argument configuration
+// with the specified name.
+// If multiple command arguments are assigned, this method returns the first one.
+// If no command argument is assigned or no such argument configuration exists, this method returns
+// an empty string.
+func (cmd Cmd) CmdArg(name string) string {
+	arr := cmd.CmdArgs(name)
+	if len(arr) == 0 {
+		return ""
+	}
+	return arr[0]
+}
+
+// CmdArgs is the method that returns the command arguments assigned to the argument configuration
+// with the specified name, according to Cmd#ArgCfgs.
+// If no such argument configuration exists, this method returns a nil slice.
+func (cmd Cmd) CmdArgs(name string) []string {
+	assigned, _, _ := distributeCmdArgs(cmd.ArgCfgs, cmd.Args)
+	for i, cfg := range cmd.ArgCfgs {
+		if cfg.Name == name {
+			return assigned[i]
+		}
+	}
+	return nil
+}
+
+// MakeArgCfgsFor is a function to make an ArgCfg array from the fields of the option store which
+// have the struct tag optpos.
+//
+// The value of optpos is the position of the command argument, like `optpos:"0"`, or "rest" for
+// the command arguments following all positioned ones, like `optpos:"rest"`.
+// A positioned field takes one required command argument, and cannot be a slice.
+// A "rest" field takes any number of command arguments, and must be a slice.
+// If a "rest" field has the struct tag `optreq:"true"`, it requires at least one command argument.
+// The Name of an ArgCfg is the value of the struct tag optarg if specified, otherwise the field
+// name, and its Desc is the value of the struct tag optdesc.
+// The fields of embedded structs can have the struct tag optpos too, but the fields of nested
+// struct fields cannot, and such a field causes ArgPosIsInvalid error.
+func MakeArgCfgsFor(options any) ([]ArgCfg, error) {
+	v := reflect.ValueOf(options)
+	if v.Kind() != reflect.Ptr {
+		return nil, errors.OptionStoreIsNotChangeable{}
+	}
+	v = v.Elem()
+
+	posFlds, err := posFieldsOf(v, "")
+	if err != nil {
+		return nil, err
+	}
+
+	type posCfg struct {
+		pos   int
+		field string
+		cfg   ArgCfg
+	}
+	var posCfgs []posCfg
+	var restCfg *ArgCfg
+
+	for _, pf := range posFlds {
+		fld := pf.fld
+		pos := fld.Tag.Get("optpos")
+
+		name := fld.Tag.Get("optarg")
+		if len(name) == 0 {
+			name = fld.Name
+		}
+		cfg := ArgCfg{Name: name, Desc: fld.Tag.Get("optdesc")}
+
+		isSlice := fld.Type.Kind() == reflect.Slice &&
+			!isParsableType(fld.Type) && !isSelfSettableType(fld.Type)
+
+		if pos == "rest" {
+			if !isSlice || restCfg != nil {
+				return nil, errors.ArgPosIsInvalid{Field: fld.Name, Pos: pos}
+			}
+			isRequired, _ := strconv.ParseBool(fld.Tag.Get("optreq"))
+			if isRequired {
+				cfg.Min = 1
+			}
+			cfg.Max = -1
+		} else {
+			n, e := strconv.Atoi(pos)
+			if e != nil || n < 0 || isSlice {
+				return nil, errors.ArgPosIsInvalid{Field: fld.Name, Pos: pos}
+			}
+			cfg.Min = 1
+			cfg.Max = 1
+		}
+
+		setter, err := newValueSetter(name, fld.Name, pf.val, fld.Tag.Get("optlayout"))
+		if err != nil {
+			return nil, err
+		}
+		cfg.onParsed = &setter
+
+		if pos == "rest" {
+			restCfg = &cfg
+		} else {
+			n, _ := strconv.Atoi(pos)
+			posCfgs = append(posCfgs, posCfg{pos: n, field: fld.Name, cfg: cfg})
+		}
+	}
+
+	sort.SliceStable(posCfgs, func(i, j int) bool {
+		return posCfgs[i].pos < posCfgs[j].pos
+	})
+
+	var argCfgs []ArgCfg
+	for i, pc := range posCfgs {
+		if pc.pos != i {
+			return nil, errors.ArgPosIsInvalid{Field: pc.field, Pos: strconv.Itoa(pc.pos)}
+		}
+		argCfgs = append(argCfgs, pc.cfg)
+	}
+	if restCfg != nil {
+		argCfgs = append(argCfgs, *restCfg)
+	}
+
+	return argCfgs, nil
+}
+
+type posField struct {
+	fld reflect.StructField
+	val reflect.Value
+}
+
+// posFieldsOf is the function that collects the fields which have the struct tag optpos from the
+// struct value and its embedded structs.
+// If a field in a nested struct field has the struct tag optpos, this function returns
+// ArgPosIsInvalid error of which Field is the field names joined with dots, like DB.Files.
+func posFieldsOf(v reflect.Value, keyPrefix string) ([]posField, error) {
+	t := v.Type()
+	var posFlds []posField
+
+	for i := 0; i < t.NumField(); i++ {
+		fld := t.Field(i)
+		if pos, ok := fld.Tag.Lookup("optpos"); ok {
+			if len(keyPrefix) > 0 {
+				return nil, errors.ArgPosIsInvalid{Field: keyPrefix + fld.Name, Pos: pos}
+			}
+			posFlds = append(posFlds, posField{fld: fld, val: v.Field(i)})
+			continue
+		}
+
+		if isNestedStructType(fld.Type) {
+			key := keyPrefix
+			if !fld.Anonymous {
+				key += fld.Name + "."
+			}
+			arr, err := posFieldsOf(v.Field(i), key)
+			if err != nil {
+				return nil, err
+			}
+			posFlds = append(posFlds, arr...)
+		}
+	}
+
+	return posFlds, nil
+}
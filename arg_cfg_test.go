@@ -0,0 +1,393 @@
+package cliargs_test
+
+import (
+	goerrors "errors"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sttk/cliargs"
+	"github.com/sttk/cliargs/errors"
+	"github.com/sttk/cliargs/validators"
+)
+
+func TestParseWith_argCfgs_requiredAndVariadic(t *testing.T) {
+	cmd := cliargs.NewCmdFromArgs("app", []string{"-v", "a.txt", "b.txt", "c.txt", "out"})
+	cmd.ArgCfgs = []cliargs.ArgCfg{
+		cliargs.ArgCfg{Name: "src", Min: 1, Max: -1},
+		cliargs.ArgCfg{Name: "dest", Min: 1},
+	}
+	err := cmd.ParseWith([]cliargs.OptCfg{
+		cliargs.OptCfg{Names: []string{"v"}},
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, cmd.Args, []string{"a.txt", "b.txt", "c.txt", "out"})
+	assert.Equal(t, cmd.CmdArgs("src"), []string{"a.txt", "b.txt", "c.txt"})
+	assert.Equal(t, cmd.CmdArg("src"), "a.txt")
+	assert.Equal(t, cmd.CmdArgs("dest"), []string{"out"})
+	assert.Equal(t, cmd.CmdArg("dest"), "out")
+	assert.Nil(t, cmd.CmdArgs("none"))
+	assert.Equal(t, cmd.CmdArg("none"), "")
+}
+
+func TestParseWith_argCfgs_optionalArg(t *testing.T) {
+	argCfgs := []cliargs.ArgCfg{
+		cliargs.ArgCfg{Name: "file", Min: 1},
+		cliargs.ArgCfg{Name: "mode"},
+	}
+
+	cmd := cliargs.NewCmdFromArgs("app", []string{"a.txt"})
+	cmd.ArgCfgs = argCfgs
+	err := cmd.ParseWith([]cliargs.OptCfg{})
+
+	assert.Nil(t, err)
+	assert.Equal(t, cmd.CmdArg("file"), "a.txt")
+	assert.Equal(t, cmd.CmdArgs("mode"), []string{})
+	assert.Equal(t, cmd.CmdArg("mode"), "")
+
+	cmd = cliargs.NewCmdFromArgs("app", []string{"a.txt", "rw"})
+	cmd.ArgCfgs = argCfgs
+	err = cmd.ParseWith([]cliargs.OptCfg{})
+
+	assert.Nil(t, err)
+	assert.Equal(t, cmd.CmdArg("file"), "a.txt")
+	assert.Equal(t, cmd.CmdArg("mode"), "rw")
+}
+
+func TestParseWith_argCfgs_missingCmdArg(t *testing.T) {
+	cmd := cliargs.NewCmdFromArgs("app", []string{"a.txt"})
+	cmd.ArgCfgs = []cliargs.ArgCfg{
+		cliargs.ArgCfg{Name: "src", Min: 1, Max: -1},
+		cliargs.ArgCfg{Name: "dest", Min: 1},
+	}
+	err := cmd.ParseWith([]cliargs.OptCfg{})
+
+	switch e := err.(type) {
+	case errors.MissingCmdArg:
+		assert.Equal(t, e.Name, "dest")
+	default:
+		assert.Fail(t, err.Error())
+	}
+	assert.Equal(t, err.Error(), "MissingCmdArg{Name:dest}")
+	assert.Equal(t, errors.Message(err), "missing argument 'dest'")
+	assert.Equal(t, cmd.CmdArgs("src"), []string{"a.txt"})
+	assert.Equal(t, cmd.CmdArgs("dest"), []string{})
+}
+
+func TestParseWith_argCfgs_tooManyCmdArgs(t *testing.T) {
+	cmd := cliargs.NewCmdFromArgs("app", []string{"a.txt", "b.txt", "c.txt"})
+	cmd.ArgCfgs = []cliargs.ArgCfg{
+		cliargs.ArgCfg{Name: "file", Min: 1},
+	}
+	err := cmd.ParseWith([]cliargs.OptCfg{})
+
+	switch e := err.(type) {
+	case errors.TooManyCmdArgs:
+		assert.Equal(t, e.Args, []string{"b.txt", "c.txt"})
+	default:
+		assert.Fail(t, err.Error())
+	}
+	assert.Equal(t, err.Error(), "TooManyCmdArgs{Args:[b.txt c.txt]}")
+	assert.Equal(t, errors.Message(err), "too many arguments: 'b.txt', 'c.txt'")
+}
+
+func TestParseWith_argCfgs_fixedCount(t *testing.T) {
+	argCfgs := []cliargs.ArgCfg{
+		cliargs.ArgCfg{Name: "point", Min: 2},
+	}
+
+	cmd := cliargs.NewCmdFromArgs("app", []string{"1", "2"})
+	cmd.ArgCfgs = argCfgs
+	err := cmd.ParseWith([]cliargs.OptCfg{})
+	assert.Nil(t, err)
+	assert.Equal(t, cmd.CmdArgs("point"), []string{"1", "2"})
+
+	cmd = cliargs.NewCmdFromArgs("app", []string{"1"})
+	cmd.ArgCfgs = argCfgs
+	err = cmd.ParseWith([]cliargs.OptCfg{})
+	assert.True(t, goerrors.Is(err, errors.MissingCmdArg{Name: "point"}))
+}
+
+func TestParseWith_argCfgs_validator(t *testing.T) {
+	cmd := cliargs.NewCmdFromArgs("app", []string{"1", "x", "3"})
+	cmd.ArgCfgs = []cliargs.ArgCfg{
+		cliargs.ArgCfg{Name: "num", Max: -1, Validator: &validators.ValidateInt},
+	}
+	err := cmd.ParseWith([]cliargs.OptCfg{})
+
+	switch e := err.(type) {
+	case errors.CmdArgIsInvalid:
+		assert.Equal(t, e.Name, "num")
+		assert.Equal(t, e.Arg, "x")
+		var ee *strconv.NumError
+		assert.True(t, goerrors.As(e, &ee))
+	default:
+		assert.Fail(t, err.Error())
+	}
+	assert.Equal(t, errors.Message(err),
+		"invalid argument 'x' for 'num': strconv.ParseInt: parsing \"x\": invalid syntax")
+}
+
+func TestParseWith_argCfgs_collectsAllErrs(t *testing.T) {
+	cmd := cliargs.NewCmdFromArgs("app", []string{"--foo"})
+	cmd.SetCollectsAllErrs(true)
+	cmd.ArgCfgs = []cliargs.ArgCfg{
+		cliargs.ArgCfg{Name: "file", Min: 1},
+	}
+	err := cmd.ParseWith([]cliargs.OptCfg{})
+
+	switch e := err.(type) {
+	case errors.ErrorList:
+		assert.Equal(t, len(e.Errs), 2)
+		assert.True(t, goerrors.Is(e.Errs[1], errors.MissingCmdArg{Name: "file"}))
+	default:
+		assert.Fail(t, err.Error())
+	}
+}
+
+func TestParseUntilSubCmdWith_argCfgsAreNotChecked(t *testing.T) {
+	cmd := cliargs.NewCmdFromArgs("app", []string{"sub", "a", "b"})
+	cmd.ArgCfgs = []cliargs.ArgCfg{}
+	subCmd, err := cmd.ParseUntilSubCmdWith([]cliargs.OptCfg{})
+
+	assert.Nil(t, err)
+	assert.Equal(t, subCmd.Name, "sub")
+}
+
+func TestResetArgs_keepsArgCfgs(t *testing.T) {
+	cmd := cliargs.NewCmdFromArgs("app", []string{})
+	cmd.ArgCfgs = []cliargs.ArgCfg{cliargs.ArgCfg{Name: "file", Min: 1}}
+	err := cmd.ParseWith([]cliargs.OptCfg{})
+	assert.True(t, goerrors.Is(err, errors.MissingCmdArg{Name: "file"}))
+
+	cmd.ResetArgs([]string{"a.txt"})
+	err = cmd.ParseWith([]cliargs.OptCfg{})
+	assert.Nil(t, err)
+	assert.Equal(t, cmd.CmdArg("file"), "a.txt")
+}
+
+func TestParseFor_optpos(t *testing.T) {
+	type MyOptions struct {
+		Verbose bool     `optcfg:"verbose,v"`
+		Src     string   `optpos:"0" optarg:"SRC" optdesc:"Source file."`
+		Count   int      `optpos:"1"`
+		Rest    []string `optpos:"rest"`
+	}
+	options := MyOptions{}
+
+	cmd := cliargs.NewCmdFromArgs("app", []string{"-v", "a.txt", "3", "x", "y"})
+	err := cmd.ParseFor(&options)
+
+	assert.Nil(t, err)
+	assert.True(t, options.Verbose)
+	assert.Equal(t, options.Src, "a.txt")
+	assert.Equal(t, options.Count, 3)
+	assert.Equal(t, options.Rest, []string{"x", "y"})
+
+	assert.Equal(t, len(cmd.OptCfgs), 1)
+	assert.Equal(t, len(cmd.ArgCfgs), 3)
+	assert.Equal(t, cmd.ArgCfgs[0].Name, "SRC")
+	assert.Equal(t, cmd.ArgCfgs[0].Desc, "Source file.")
+	assert.Equal(t, cmd.ArgCfgs[1].Name, "Count")
+	assert.Equal(t, cmd.ArgCfgs[2].Name, "Rest")
+	assert.Equal(t, cmd.CmdArg("SRC"), "a.txt")
+
+	options = MyOptions{}
+	cmd = cliargs.NewCmdFromArgs("app", []string{"a.txt", "3"})
+	err = cmd.ParseFor(&options)
+
+	assert.Nil(t, err)
+	assert.Equal(t, options.Src, "a.txt")
+	assert.Equal(t, options.Count, 3)
+	assert.Nil(t, options.Rest)
+}
+
+func TestParseFor_argCfgsAreNotKeptForAnotherStore(t *testing.T) {
+	type WithPos struct {
+		File string `optpos:"0"`
+	}
+	type WithoutPos struct {
+		Verbose bool `optcfg:"verbose,v"`
+	}
+
+	a := WithPos{}
+	cmd := cliargs.NewCmdFromArgs("app", []string{"x"})
+	err := cmd.ParseFor(&a)
+	assert.Nil(t, err)
+	assert.Equal(t, a.File, "x")
+	assert.Equal(t, len(cmd.ArgCfgs), 1)
+
+	cmd.ResetArgs([]string{"a", "b", "c"})
+	assert.Equal(t, len(cmd.ArgCfgs), 0)
+
+	b := WithoutPos{}
+	err = cmd.ParseFor(&b)
+	assert.Nil(t, err)
+	assert.Equal(t, a.File, "x")
+	assert.Equal(t, cmd.Args, []string{"a", "b", "c"})
+	assert.Equal(t, len(cmd.ArgCfgs), 0)
+}
+
+func TestParseFor_argCfgsAreClearedWithoutResetArgs(t *testing.T) {
+	type WithPos struct {
+		File string `optpos:"0"`
+	}
+	type WithoutPos struct {
+		Verbose bool `optcfg:"verbose,v"`
+	}
+
+	a := WithPos{}
+	cmd := cliargs.NewCmdFromArgs("app", []string{"x"})
+	err := cmd.ParseFor(&a)
+	assert.Nil(t, err)
+	assert.Equal(t, len(cmd.ArgCfgs), 1)
+
+	b := WithoutPos{}
+	err = cmd.ParseFor(&b)
+	assert.Nil(t, err)
+	assert.Equal(t, len(cmd.ArgCfgs), 0)
+}
+
+func TestParseFor_argCfgsSetByUserAreKept(t *testing.T) {
+	type WithoutPos struct {
+		Verbose bool `optcfg:"verbose,v"`
+	}
+
+	cmd := cliargs.NewCmdFromArgs("app", []string{"a.txt"})
+	cmd.ArgCfgs = []cliargs.ArgCfg{cliargs.ArgCfg{Name: "file", Min: 1}}
+	b := WithoutPos{}
+	err := cmd.ParseFor(&b)
+	assert.Nil(t, err)
+	assert.Equal(t, cmd.CmdArg("file"), "a.txt")
+
+	cmd.ResetArgs([]string{})
+	err = cmd.ParseFor(&b)
+	assert.True(t, goerrors.Is(err, errors.MissingCmdArg{Name: "file"}))
+}
+
+func TestParseFor_optpos_errors(t *testing.T) {
+	type MyOptions struct {
+		Src   string   `optpos:"0"`
+		Count int      `optpos:"1"`
+		Rest  []string `optpos:"rest" optreq:"true"`
+	}
+	options := MyOptions{}
+
+	cmd := cliargs.NewCmdFromArgs("app", []string{"a.txt", "3"})
+	err := cmd.ParseFor(&options)
+	assert.True(t, goerrors.Is(err, errors.MissingCmdArg{Name: "Rest"}))
+
+	options = MyOptions{}
+	cmd = cliargs.NewCmdFromArgs("app", []string{"a.txt", "three", "x"})
+	err = cmd.ParseFor(&options)
+
+	switch e := err.(type) {
+	case errors.CmdArgIsInvalid:
+		assert.Equal(t, e.Name, "Count")
+		assert.Equal(t, e.Arg, "three")
+		var ee *strconv.NumError
+		assert.True(t, goerrors.As(e, &ee))
+	default:
+		assert.Fail(t, err.Error())
+	}
+}
+
+func TestMakeArgCfgsFor_invalidPos(t *testing.T) {
+	type NotSequential struct {
+		Src  string `optpos:"0"`
+		Dest string `optpos:"2"`
+	}
+	_, err := cliargs.MakeArgCfgsFor(&NotSequential{})
+	assert.Equal(t, err, errors.ArgPosIsInvalid{Field: "Dest", Pos: "2"})
+
+	type RestIsNotSlice struct {
+		Rest string `optpos:"rest"`
+	}
+	_, err = cliargs.MakeArgCfgsFor(&RestIsNotSlice{})
+	assert.Equal(t, err, errors.ArgPosIsInvalid{Field: "Rest", Pos: "rest"})
+
+	type IndexIsSlice struct {
+		Src []string `optpos:"0"`
+	}
+	_, err = cliargs.MakeArgCfgsFor(&IndexIsSlice{})
+	assert.Equal(t, err, errors.ArgPosIsInvalid{Field: "Src", Pos: "0"})
+
+	type BadPos struct {
+		Src string `optpos:"first"`
+	}
+	_, err = cliargs.MakeArgCfgsFor(&BadPos{})
+	assert.Equal(t, err, errors.ArgPosIsInvalid{Field: "Src", Pos: "first"})
+	assert.Equal(t, errors.Message(err), "field 'Src' has an invalid argument position: first")
+
+	_, err = cliargs.MakeArgCfgsFor(BadPos{})
+	assert.Equal(t, err, errors.OptionStoreIsNotChangeable{})
+}
+
+func TestParseFor_optposInEmbeddedStruct(t *testing.T) {
+	type FileArgs struct {
+		Files []string `optpos:"rest"`
+	}
+	type MyOptions struct {
+		FileArgs
+		Verbose bool   `optcfg:"verbose"`
+		Cmd     string `optpos:"0"`
+	}
+	options := MyOptions{}
+
+	cmd := cliargs.NewCmdFromArgs("app", []string{"--verbose", "run", "x", "y"})
+	err := cmd.ParseFor(&options)
+
+	assert.Nil(t, err)
+	assert.True(t, options.Verbose)
+	assert.Equal(t, options.Cmd, "run")
+	assert.Equal(t, options.Files, []string{"x", "y"})
+	assert.Equal(t, len(cmd.ArgCfgs), 2)
+	assert.Equal(t, cmd.ArgCfgs[0].Name, "Cmd")
+	assert.Equal(t, cmd.ArgCfgs[1].Name, "Files")
+}
+
+func TestParseFor_optposInNestedStruct(t *testing.T) {
+	type FileArgs struct {
+		Files []string `optpos:"rest"`
+	}
+	type Inner struct {
+		FileArgs
+	}
+	type MyOptions struct {
+		Inner Inner
+	}
+	options := MyOptions{}
+
+	cmd := cliargs.NewCmdFromArgs("app", []string{"x", "y"})
+	err := cmd.ParseFor(&options)
+
+	assert.Equal(t, err, errors.ArgPosIsInvalid{Field: "Inner.Files", Pos: "rest"})
+	assert.Nil(t, options.Inner.Files)
+}
+
+func TestDispatch_argCfgs(t *testing.T) {
+	var file string
+	root := cliargs.CmdCfg{
+		SubCmds: []cliargs.CmdCfg{
+			cliargs.CmdCfg{
+				Name:    "show",
+				ArgCfgs: []cliargs.ArgCfg{cliargs.ArgCfg{Name: "file", Min: 1}},
+				Run: func(cmd cliargs.Cmd, parents []cliargs.Cmd) error {
+					file = cmd.CmdArg("file")
+					return nil
+				},
+			},
+		},
+	}
+
+	cmd := cliargs.NewCmdFromArgs("app", []string{"show", "a.txt"})
+	err := cmd.Dispatch(root)
+	assert.Nil(t, err)
+	assert.Equal(t, file, "a.txt")
+
+	cmd = cliargs.NewCmdFromArgs("app", []string{"show"})
+	err = cmd.Dispatch(root)
+	assert.True(t, goerrors.Is(err, errors.MissingCmdArg{Name: "file"}))
+}
@@ -8,19 +8,28 @@ import (
 	"fmt"
 	"os"
 	"path"
+	"strings"
+
+	"github.com/sttk/cliargs/errors"
 )
 
 // Cmd is the structure that parses command line arguments and stores them.
 // The results of parsing are stored by separating into command name, command arguments, options,
 // and option arguments.
 // And this provides methods to check if they are specified and to retrieve them.
+//
+// ArgCfgs is the field to set the argument configurations which the command arguments are checked
+// with by the parsing methods, and is kept when ResetArgs is called unless it was set by ParseFor.
 type Cmd struct {
 	Name    string
 	Args    []string
 	OptCfgs []OptCfg
+	ArgCfgs []ArgCfg
 
-	opts          map[string][]string
-	isAfterEndOpt bool
+	opts             map[string][]string
+	isAfterEndOpt    bool
+	collectsAllErrs  bool
+	isArgCfgsOfStore bool
 
 	_args []string
 }
@@ -38,9 +47,38 @@ func NewCmd() Cmd {
 		args = os.Args[1:]
 	}
 
+	return NewCmdFromArgs(name, args)
+}
+
+// NewCmdFromArgs is the function that creates a Cmd instance with the specified command name and
+// command line arguments.
+// The arguments should not include the command name.
+//
+// This function is useful to parse arguments which are not obtained from os.Args, for example,
+// an input line of a REPL, arguments read from a file, or fixtures in tests.
+func NewCmdFromArgs(name string, args []string) Cmd {
 	return Cmd{Name: name, Args: []string{}, opts: make(map[string][]string), _args: args}
 }
 
+// ResetArgs is the method that replaces the command line arguments to be parsed with the
+// specified arguments, and clears the results of the previous parsing.
+// The arguments should not include the command name.
+//
+// After calling this method, this Cmd instance can be parsed again with Parse, ParseWith,
+// ParseFor, or the ParseUntilSubCmd* methods.
+// ArgCfgs is kept, except when it was made from an option store by ParseFor.
+func (cmd *Cmd) ResetArgs(args []string) {
+	cmd.Args = []string{}
+	cmd.OptCfgs = nil
+	cmd.opts = make(map[string][]string)
+	cmd.isAfterEndOpt = false
+	if cmd.isArgCfgsOfStore {
+		cmd.ArgCfgs = nil
+		cmd.isArgCfgsOfStore = false
+	}
+	cmd._args = args
+}
+
 func (cmd Cmd) subCmd(fromIndex int, isAfterEndOpt bool) Cmd {
 	var name string
 	if len(cmd._args) > fromIndex {
@@ -53,12 +91,34 @@ func (cmd Cmd) subCmd(fromIndex int, isAfterEndOpt bool) Cmd {
 	}
 
 	return Cmd{
-		Name:          name,
-		Args:          []string{},
-		opts:          make(map[string][]string),
-		isAfterEndOpt: isAfterEndOpt,
-		_args:         args,
+		Name:            name,
+		Args:            []string{},
+		opts:            make(map[string][]string),
+		isAfterEndOpt:   isAfterEndOpt,
+		collectsAllErrs: cmd.collectsAllErrs,
+		_args:           args,
+	}
+}
+
+// SetCollectsAllErrs is the method that sets whether the parsing methods of this Cmd instance
+// collect all errors which occur during parsing.
+//
+// By default, the parsing methods return only the first error.
+// If this flag is set true, they return an ErrorList error which holds all errors, including
+// errors from validators and from setting values to an option store.
+// The Cmd instances for sub commands inherit this flag.
+func (cmd *Cmd) SetCollectsAllErrs(flag bool) {
+	cmd.collectsAllErrs = flag
+}
+
+func (cmd Cmd) toErr(errs []error) error {
+	if len(errs) == 0 {
+		return nil
+	}
+	if cmd.collectsAllErrs {
+		return errors.ErrorList{Errs: errs}
 	}
+	return errs[0]
 }
 
 // HasOpt is the method that checks whether an option with the specified name exists.
@@ -92,6 +152,25 @@ func (cmd Cmd) OptArgs(name string) []string {
 	return cmd.opts[name]
 }
 
+// OptMap is the method that returns the option arguments of the form of key=value with the
+// specified name as a map.
+// If a key is given multiple times, the last value is used.
+// An option argument without "=" becomes a key with an empty value.
+// If the option is not specified in the command line arguments, the return value of this method
+// is a nil map.
+func (cmd Cmd) OptMap(name string) map[string]string {
+	arr, exists := cmd.opts[name]
+	if !exists {
+		return nil
+	}
+	m := make(map[string]string, len(arr))
+	for _, a := range arr {
+		k, v, _ := strings.Cut(a, "=")
+		m[k] = v
+	}
+	return m
+}
+
 // String is the method that returns the string which represents the content of this instance.
 func (cmd Cmd) String() string {
 	return fmt.Sprintf("Cmd { Name: %s, Args: %v, Opts: %v }", cmd.Name, cmd.Args, cmd.opts)
@@ -0,0 +1,145 @@
+// Copyright (C) 2026 Takayuki Sato. All Rights Reserved.
+// This program is free software under MIT License.
+// See the file LICENSE in this distribution for more details.
+
+package cliargs
+
+import (
+	"os"
+)
+
+// CmdCfg is the struct that represents a command configuration, which is a node of a command tree.
+// A command configuration consists of fields: Name, Aliases, OptCfgs, OptStore, ArgCfgs, SubCmds,
+// Run, and Desc.
+//
+// The Name field is the name of the command.
+// The Name of the root command configuration is not used for dispatching because the root command
+// is not specified in command line arguments.
+//
+// The Aliases field is the array of the alternative names of the command.
+//
+// The OptCfgs field is the array of option configurations of the command, and the OptStore field
+// is the option store of the command.
+// If OptStore is not nil, the command line arguments for the command are parsed with
+// Cmd#ParseFor or Cmd#ParseUntilSubCmdFor, otherwise parsed with Cmd#ParseWith or
+// Cmd#ParseUntilSubCmdWith and OptCfgs.
+//
+// The ArgCfgs field is the array of argument configurations of the command, which are used to
+// check the command arguments of a command having no sub command.
+//
+// The SubCmds field is the array of the command configurations of the sub commands.
+// If this field is not empty, the first command argument is regarded as a sub command.
+//
+// The Run field is the function which is called when the command is dispatched.
+// This function takes the Cmd instance of the command and the Cmd instances of its ancestor
+// commands, ordered from the root command.
+//
+// Desc is the field to set the description of the command.
+type CmdCfg struct {
+	Name     string
+	Aliases  []string
+	OptCfgs  []OptCfg
+	OptStore any
+	ArgCfgs  []ArgCfg
+	SubCmds  []CmdCfg
+	Run      func(cmd Cmd, parents []Cmd) error
+	Desc     string
+}
+
+// Dispatch is the method which parses command line arguments along a command tree and calls the
+// Run function of the command configuration which matches the command line arguments.
+//
+// The command configuration passed as the argument is the root of the command tree.
+// If a command configuration has sub command configurations, this method parses command line
+// arguments until the first command argument, and finds the sub command configuration of which
+// Name or Aliases matches that command argument.
+// Then this method parses the rest of command line arguments with the found sub command
+// configuration in the same way.
+// If no sub command configuration matches, this method returns UnconfiguredSubCmd error, which has
+// the names of the sub commands similar to that command argument as its suggestions.
+//
+// If a command configuration has no sub command configuration, or no command argument is given
+// for a command configuration having sub command configurations, this method calls the Run
+// function of the command configuration.
+// The Name of the Cmd instance passed to the Run function of a sub command is the Name of the
+// sub command configuration, even if an alias is specified in command line arguments.
+//
+// If the first command line argument is the reserved argument: __complete, this method writes
+// completion candidates to os.Stdout with Cmd#Complete, and returns without calling any Run
+// function.
+func (cmd *Cmd) Dispatch(cmdCfg CmdCfg) error {
+	isCompletion, err := cmd.Complete(cmdCfg, os.Stdout)
+	if isCompletion {
+		return err
+	}
+	return cmd.dispatch(cmdCfg, []Cmd{})
+}
+
+func (cmd *Cmd) dispatch(cmdCfg CmdCfg, parents []Cmd) error {
+	if len(cmdCfg.SubCmds) == 0 {
+		if len(cmdCfg.ArgCfgs) > 0 {
+			cmd.ArgCfgs = cmdCfg.ArgCfgs
+		}
+		var err error
+		if cmdCfg.OptStore != nil {
+			err = cmd.ParseFor(cmdCfg.OptStore)
+		} else {
+			err = cmd.ParseWith(cmdCfg.OptCfgs)
+		}
+		if err != nil {
+			return err
+		}
+		return cmdCfg.run(*cmd, parents)
+	}
+
+	var subCmd Cmd
+	var err error
+	if cmdCfg.OptStore != nil {
+		subCmd, err = cmd.ParseUntilSubCmdFor(cmdCfg.OptStore)
+	} else {
+		subCmd, err = cmd.ParseUntilSubCmdWith(cmdCfg.OptCfgs)
+	}
+	if err != nil {
+		return err
+	}
+
+	if len(subCmd.Name) == 0 {
+		return cmdCfg.run(*cmd, parents)
+	}
+
+	for _, subCfg := range cmdCfg.SubCmds {
+		if subCfg.matches(subCmd.Name) {
+			subCmd.Name = subCfg.Name
+			ancestors := make([]Cmd, len(parents), len(parents)+1)
+			copy(ancestors, parents)
+			ancestors = append(ancestors, *cmd)
+			return subCmd.dispatch(subCfg, ancestors)
+		}
+	}
+
+	var names []string
+	for _, subCfg := range cmdCfg.SubCmds {
+		names = append(names, subCfg.Name)
+		names = append(names, subCfg.Aliases...)
+	}
+	return cmd.toErr([]error{UnconfiguredSubCmdOf(subCmd.Name, names)})
+}
+
+func (cmdCfg CmdCfg) matches(name string) bool {
+	if cmdCfg.Name == name {
+		return true
+	}
+	for _, alias := range cmdCfg.Aliases {
+		if alias == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (cmdCfg CmdCfg) run(cmd Cmd, parents []Cmd) error {
+	if cmdCfg.Run == nil {
+		return nil
+	}
+	return cmdCfg.Run(cmd, parents)
+}
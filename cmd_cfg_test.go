@@ -0,0 +1,271 @@
+package cliargs_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sttk/cliargs"
+	"github.com/sttk/cliargs/errors"
+)
+
+func newTestCmdTree(log *[]string) cliargs.CmdCfg {
+	var record = func(cmd cliargs.Cmd, parents []cliargs.Cmd) error {
+		s := ""
+		for _, p := range parents {
+			s += p.Name + " > "
+		}
+		s += cmd.Name + fmt.Sprintf(" %v", cmd.Args)
+		*log = append(*log, s)
+		return nil
+	}
+
+	return cliargs.CmdCfg{
+		Name: "app",
+		OptCfgs: []cliargs.OptCfg{
+			cliargs.OptCfg{Names: []string{"verbose", "v"}},
+		},
+		Run: record,
+		SubCmds: []cliargs.CmdCfg{
+			cliargs.CmdCfg{
+				Name:    "remote",
+				Aliases: []string{"rem", "r"},
+				OptCfgs: []cliargs.OptCfg{
+					cliargs.OptCfg{Names: []string{"dry-run", "n"}},
+				},
+				SubCmds: []cliargs.CmdCfg{
+					cliargs.CmdCfg{
+						Name: "add",
+						OptCfgs: []cliargs.OptCfg{
+							cliargs.OptCfg{Names: []string{"track", "t"}, HasArg: true},
+						},
+						Run: record,
+					},
+					cliargs.CmdCfg{
+						Name: "remove",
+						Run:  record,
+					},
+				},
+			},
+			cliargs.CmdCfg{
+				Name: "status",
+				Run:  record,
+			},
+		},
+	}
+}
+
+func TestDispatch_rootCmd(t *testing.T) {
+	log := []string{}
+	cmdCfg := newTestCmdTree(&log)
+
+	cmd := cliargs.NewCmdFromArgs("app", []string{"-v"})
+	err := cmd.Dispatch(cmdCfg)
+
+	assert.Nil(t, err)
+	assert.Equal(t, log, []string{"app []"})
+	assert.True(t, cmd.HasOpt("verbose"))
+}
+
+func TestDispatch_subCmd(t *testing.T) {
+	log := []string{}
+	cmdCfg := newTestCmdTree(&log)
+
+	cmd := cliargs.NewCmdFromArgs("app", []string{"-v", "status", "foo"})
+	err := cmd.Dispatch(cmdCfg)
+
+	assert.Nil(t, err)
+	assert.Equal(t, log, []string{"app > status [foo]"})
+}
+
+func TestDispatch_subSubCmd(t *testing.T) {
+	log := []string{}
+	cmdCfg := newTestCmdTree(&log)
+
+	var optsOfEachLevel []bool
+	cmdCfg.SubCmds[0].SubCmds[0].Run = func(cmd cliargs.Cmd, parents []cliargs.Cmd) error {
+		optsOfEachLevel = []bool{
+			parents[0].HasOpt("verbose"),
+			parents[1].HasOpt("dry-run"),
+		}
+		log = append(log, cmd.Name+" "+cmd.OptArg("track"))
+		return nil
+	}
+
+	cmd := cliargs.NewCmdFromArgs("app", []string{
+		"-v", "remote", "-n", "add", "--track", "main", "origin",
+	})
+	err := cmd.Dispatch(cmdCfg)
+
+	assert.Nil(t, err)
+	assert.Equal(t, log, []string{"add main"})
+	assert.Equal(t, optsOfEachLevel, []bool{true, true})
+}
+
+func TestDispatch_subCmdAlias(t *testing.T) {
+	log := []string{}
+	cmdCfg := newTestCmdTree(&log)
+
+	cmd := cliargs.NewCmdFromArgs("app", []string{"rem", "remove", "origin"})
+	err := cmd.Dispatch(cmdCfg)
+
+	assert.Nil(t, err)
+	assert.Equal(t, log, []string{"app > remote > remove [origin]"})
+}
+
+func TestDispatch_noSubCmdAndNoRun(t *testing.T) {
+	log := []string{}
+	cmdCfg := newTestCmdTree(&log)
+
+	cmd := cliargs.NewCmdFromArgs("app", []string{"remote", "-n"})
+	err := cmd.Dispatch(cmdCfg)
+
+	assert.Nil(t, err)
+	assert.Equal(t, log, []string{})
+}
+
+func TestDispatch_unconfiguredSubCmd(t *testing.T) {
+	log := []string{}
+	cmdCfg := newTestCmdTree(&log)
+
+	cmd := cliargs.NewCmdFromArgs("app", []string{"remote", "rename", "a", "b"})
+	err := cmd.Dispatch(cmdCfg)
+
+	assert.Equal(t, err, errors.UnconfiguredSubCmd{Name: "rename"})
+	assert.Equal(t, err.Error(), "UnconfiguredSubCmd{Name:rename}")
+	assert.Equal(t, log, []string{})
+}
+
+func TestDispatch_subCmdAfterEndOpt(t *testing.T) {
+	log := []string{}
+	cmdCfg := newTestCmdTree(&log)
+
+	cmd := cliargs.NewCmdFromArgs("app", []string{"--", "status", "-v"})
+	err := cmd.Dispatch(cmdCfg)
+
+	assert.Nil(t, err)
+	assert.Equal(t, log, []string{"app > status [-v]"})
+}
+
+func TestDispatch_parseError(t *testing.T) {
+	log := []string{}
+	cmdCfg := newTestCmdTree(&log)
+
+	cmd := cliargs.NewCmdFromArgs("app", []string{"status", "--foo"})
+	err := cmd.Dispatch(cmdCfg)
+
+	assert.Equal(t, err, errors.UnconfiguredOption{Option: "foo"})
+	assert.Equal(t, log, []string{})
+
+	cmd = cliargs.NewCmdFromArgs("app", []string{"--foo", "status"})
+	err = cmd.Dispatch(cmdCfg)
+
+	assert.Equal(t, err, errors.UnconfiguredOption{Option: "foo"})
+	assert.Equal(t, log, []string{})
+}
+
+func TestDispatch_runError(t *testing.T) {
+	cmdCfg := cliargs.CmdCfg{
+		Name: "app",
+		SubCmds: []cliargs.CmdCfg{
+			cliargs.CmdCfg{
+				Name: "foo",
+				Run: func(cmd cliargs.Cmd, parents []cliargs.Cmd) error {
+					return fmt.Errorf("failed")
+				},
+			},
+		},
+	}
+
+	cmd := cliargs.NewCmdFromArgs("app", []string{"foo"})
+	err := cmd.Dispatch(cmdCfg)
+
+	assert.Equal(t, err.Error(), "failed")
+}
+
+func TestDispatch_withOptStore(t *testing.T) {
+	type RootOptions struct {
+		Verbose bool `optcfg:"verbose,v"`
+	}
+	type BuildOptions struct {
+		Jobs int    `optcfg:"jobs,j=1"`
+		Out  string `optcfg:"out,o"`
+	}
+	rootOptions := RootOptions{}
+	buildOptions := BuildOptions{}
+
+	var args []string
+	cmdCfg := cliargs.CmdCfg{
+		OptStore: &rootOptions,
+		SubCmds: []cliargs.CmdCfg{
+			cliargs.CmdCfg{
+				Name:     "build",
+				OptStore: &buildOptions,
+				Run: func(cmd cliargs.Cmd, parents []cliargs.Cmd) error {
+					args = cmd.Args
+					return nil
+				},
+			},
+		},
+	}
+
+	cmd := cliargs.NewCmdFromArgs("app", []string{"-v", "build", "-j", "4", "x", "-o", "y"})
+	err := cmd.Dispatch(cmdCfg)
+
+	assert.Nil(t, err)
+	assert.True(t, rootOptions.Verbose)
+	assert.Equal(t, buildOptions.Jobs, 4)
+	assert.Equal(t, buildOptions.Out, "y")
+	assert.Equal(t, args, []string{"x"})
+}
+
+func TestDispatch_collectAllErrs(t *testing.T) {
+	log := []string{}
+	cmdCfg := newTestCmdTree(&log)
+
+	cmd := cliargs.NewCmdFromArgs("app", []string{"remote", "rename"})
+	cmd.SetCollectsAllErrs(true)
+	err := cmd.Dispatch(cmdCfg)
+
+	assert.Equal(t, err, errors.ErrorList{Errs: []error{
+		errors.UnconfiguredSubCmd{Name: "rename"},
+	}})
+
+	cmd = cliargs.NewCmdFromArgs("app", []string{"status", "--foo", "--bar"})
+	cmd.SetCollectsAllErrs(true)
+	err = cmd.Dispatch(cmdCfg)
+
+	assert.Equal(t, err, errors.ErrorList{Errs: []error{
+		errors.UnconfiguredOption{Option: "foo"},
+		errors.UnconfiguredOption{Option: "bar"},
+	}})
+	assert.Equal(t, log, []string{})
+}
+
+func TestDispatch_unconfiguredSubCmdHasSuggestions(t *testing.T) {
+	log := []string{}
+	cmdCfg := newTestCmdTree(&log)
+
+	cmd := cliargs.NewCmdFromArgs("app", []string{"remote", "remvoe"})
+	err := cmd.Dispatch(cmdCfg)
+
+	switch e := err.(type) {
+	case errors.UnconfiguredSubCmd:
+		assert.Equal(t, e.Name, "remvoe")
+		assert.Equal(t, e.Suggestions(), []string{"remove"})
+	default:
+		assert.Fail(t, err.Error())
+	}
+
+	cmd = cliargs.NewCmdFromArgs("app", []string{"stat"})
+	err = cmd.Dispatch(cmdCfg)
+
+	switch e := err.(type) {
+	case errors.UnconfiguredSubCmd:
+		assert.Equal(t, e.Suggestions(), []string{"status"})
+	default:
+		assert.Fail(t, err.Error())
+	}
+	assert.Equal(t, log, []string{})
+}
@@ -65,6 +65,64 @@ func TestCmd_NewCmd_empty(t *testing.T) {
 	assert.Equal(t, cmd.OptArgs("foo"), []string(nil))
 }
 
+func TestCmd_NewCmdFromArgs(t *testing.T) {
+	cmd := NewCmdFromArgs("app", []string{"--foo", "bar"})
+	assert.Equal(t, cmd.Name, "app")
+	assert.Equal(t, cmd.Args, []string{})
+	assert.Equal(t, cmd.String(), "Cmd { Name: app, Args: [], Opts: map[] }")
+
+	assert.Equal(t, cmd._args, []string{"--foo", "bar"})
+
+	assert.Equal(t, cmd.HasOpt("foo"), false)
+	assert.Equal(t, cmd.OptArg("foo"), "")
+	assert.Equal(t, cmd.OptArgs("foo"), []string(nil))
+}
+
+func TestCmd_NewCmdFromArgs_withNoArgs(t *testing.T) {
+	cmd := NewCmdFromArgs("app", nil)
+	assert.Equal(t, cmd.Name, "app")
+	assert.Equal(t, cmd.Args, []string{})
+	assert.Equal(t, cmd._args, []string(nil))
+}
+
+func TestCmd_NewCmdFromArgs_doesNotUseOsArgs(t *testing.T) {
+	defer reset()
+	os.Args = []string{"/path/to/other", "--baz"}
+
+	cmd := NewCmdFromArgs("app", []string{"--foo"})
+	assert.Equal(t, cmd.Name, "app")
+	assert.Equal(t, cmd._args, []string{"--foo"})
+}
+
+func TestCmd_ResetArgs(t *testing.T) {
+	cmd := NewCmdFromArgs("app", []string{"--foo", "bar"})
+	err := cmd.Parse()
+	assert.Nil(t, err)
+	assert.Equal(t, cmd.Args, []string{"bar"})
+	assert.True(t, cmd.HasOpt("foo"))
+
+	cmd.ResetArgs([]string{"--", "--baz", "qux"})
+	assert.Equal(t, cmd.Name, "app")
+	assert.Equal(t, cmd.Args, []string{})
+	assert.Equal(t, cmd.isAfterEndOpt, false)
+	assert.Equal(t, cmd._args, []string{"--", "--baz", "qux"})
+	assert.False(t, cmd.HasOpt("foo"))
+
+	err = cmd.Parse()
+	assert.Nil(t, err)
+	assert.Equal(t, cmd.Args, []string{"--baz", "qux"})
+	assert.False(t, cmd.HasOpt("foo"))
+	assert.False(t, cmd.HasOpt("baz"))
+
+	cmd.ResetArgs([]string{"-b"})
+	assert.Equal(t, cmd.Args, []string{})
+
+	err = cmd.Parse()
+	assert.Nil(t, err)
+	assert.Equal(t, cmd.Args, []string{})
+	assert.True(t, cmd.HasOpt("b"))
+}
+
 func TestCmd_subCmd(t *testing.T) {
 	cmd := NewCmd()
 	cmd._args = []string{"--foo", "-b", "qux", "--corge"}
@@ -133,3 +191,32 @@ func TestCmd_NewCmd_OptArgs(t *testing.T) {
 	assert.Equal(t, cmd.OptArgs("qux"), []string{"A", "B"})
 	assert.Equal(t, cmd.OptArgs("quux"), []string(nil))
 }
+
+func TestCmd_NewCmd_OptMap(t *testing.T) {
+	defer reset()
+
+	cmd := NewCmd()
+	cmd.opts["foo-bar"] = []string{}
+	cmd.opts["baz"] = []string{"a=1", "b=2=3", "a=4", "c"}
+
+	assert.Equal(t, cmd.OptMap("foo-bar"), map[string]string{})
+	assert.Equal(t, cmd.OptMap("baz"), map[string]string{"a": "4", "b": "2=3", "c": ""})
+	assert.Equal(t, cmd.OptMap("quux"), map[string]string(nil))
+}
+
+func TestCmd_SetCollectsAllErrs(t *testing.T) {
+	cmd := NewCmdFromArgs("app", []string{"--foo", "bar", "--baz"})
+	assert.False(t, cmd.collectsAllErrs)
+
+	cmd.SetCollectsAllErrs(true)
+	assert.True(t, cmd.collectsAllErrs)
+
+	subCmd := cmd.subCmd(1, false)
+	assert.True(t, subCmd.collectsAllErrs)
+
+	cmd.ResetArgs([]string{})
+	assert.True(t, cmd.collectsAllErrs)
+
+	cmd.SetCollectsAllErrs(false)
+	assert.False(t, cmd.collectsAllErrs)
+}
@@ -0,0 +1,616 @@
+// Copyright (C) 2026 Takayuki Sato. All Rights Reserved.
+// This program is free software under MIT License.
+// See the file LICENSE in this distribution for more details.
+
+package cliargs
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+// completeArg is the reserved command argument with which a completion script asks the program
+// for completion candidates.
+const completeArg = "__complete"
+
+type complNode struct {
+	path    string
+	opts    []complOpt
+	subCmds []complSubCmd
+}
+
+type complOpt struct {
+	names     []string
+	hasArg    bool
+	desc      string
+	completer *func(string) []string
+}
+
+type complSubCmd struct {
+	names []string
+	desc  string
+	path  string
+}
+
+func newComplNodes(cmdCfg CmdCfg, path string, nodes []complNode) ([]complNode, error) {
+	optCfgs := cmdCfg.OptCfgs
+	if cmdCfg.OptStore != nil {
+		cfgs, err := MakeOptCfgsFor(cmdCfg.OptStore)
+		if err != nil {
+			return nil, err
+		}
+		optCfgs = cfgs
+	}
+
+	node := complNode{path: path}
+
+	for _, cfg := range optCfgs {
+		storeKey := storeKeyOf(cfg)
+		if len(storeKey) == 0 || storeKey == anyOption {
+			continue
+		}
+
+		var names []string
+		for _, nm := range cfg.Names {
+			if len(nm) > 0 {
+				names = append(names, optNameWithHyphens(nm))
+			}
+		}
+		if len(names) == 0 {
+			names = []string{optNameWithHyphens(storeKey)}
+		}
+
+		node.opts = append(node.opts, complOpt{
+			names:     names,
+			hasArg:    cfg.HasArg,
+			desc:      firstLine(cfg.Desc),
+			completer: cfg.Completer,
+		})
+	}
+
+	for _, sub := range cmdCfg.SubCmds {
+		names := append([]string{sub.Name}, sub.Aliases...)
+		node.subCmds = append(node.subCmds, complSubCmd{
+			names: names,
+			desc:  firstLine(sub.Desc),
+			path:  path + " " + sub.Name,
+		})
+	}
+
+	nodes = append(nodes, node)
+
+	for i, sub := range cmdCfg.SubCmds {
+		var err error
+		nodes, err = newComplNodes(sub, node.subCmds[i].path, nodes)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return nodes, nil
+}
+
+// takesArgMark returns the mark which a completion script sets when this option is found: "2" if
+// the option argument is completed by the program itself, or "1" if it is completed as a file path.
+func (opt complOpt) takesArgMark() string {
+	if opt.completer != nil {
+		return "2"
+	}
+	return "1"
+}
+
+func (node complNode) findOpt(name string) (complOpt, bool) {
+	for _, opt := range node.opts {
+		for _, nm := range opt.names {
+			if nm == name {
+				return opt, true
+			}
+		}
+	}
+	return complOpt{}, false
+}
+
+func (opt complOpt) complete(prefix, head string) []string {
+	if !opt.hasArg || opt.completer == nil {
+		return nil
+	}
+	var cands []string
+	for _, c := range (*opt.completer)(prefix) {
+		if strings.HasPrefix(c, prefix) {
+			cands = append(cands, head+c)
+		}
+	}
+	return cands
+}
+
+// completeAttachedArg completes the option argument attached directly to a short option, like
+// -pdev.
+// The preceding short options combined with it, like -v of -vpdev, are skipped.
+func (node complNode) completeAttachedArg(word string) []string {
+	for i, r := range word {
+		if i == 0 {
+			continue
+		}
+		opt, ok := node.findOpt("-" + string(r))
+		if !ok {
+			return nil
+		}
+		if opt.hasArg {
+			j := i + utf8.RuneLen(r)
+			return opt.complete(word[j:], word[:j])
+		}
+	}
+	return nil
+}
+
+func optNameWithHyphens(name string) string {
+	if len([]rune(name)) == 1 {
+		return "-" + name
+	}
+	return "--" + name
+}
+
+func firstLine(text string) string {
+	i := strings.IndexByte(text, '\n')
+	if i >= 0 {
+		text = text[:i]
+	}
+	return strings.TrimSpace(strings.ReplaceAll(text, "\t", " "))
+}
+
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+func shellFuncName(name string) string {
+	var b strings.Builder
+	for _, r := range name {
+		if ('a' <= r && r <= 'z') || ('A' <= r && r <= 'Z') || ('0' <= r && r <= '9') {
+			b.WriteRune(r)
+		} else {
+			b.WriteRune('_')
+		}
+	}
+	return b.String()
+}
+
+// MakeBashCompletion is the function that creates a bash completion script from a command
+// configuration.
+// The Name of the command configuration is used as the command name for which the completion is
+// registered.
+// The option names listed in the script are taken from the OptCfgs or the OptStore of each
+// command configuration, and the sub command names are taken from its SubCmds, recursively.
+//
+// The created script can be loaded like: source <(app completion bash).
+func MakeBashCompletion(cmdCfg CmdCfg) (string, error) {
+	nodes, err := newComplNodes(cmdCfg, cmdCfg.Name, nil)
+	if err != nil {
+		return "", err
+	}
+
+	fn := "_" + shellFuncName(cmdCfg.Name) + "_completion"
+
+	var b strings.Builder
+	b.WriteString("# bash completion for " + cmdCfg.Name + "\n\n")
+	b.WriteString(fn + "() {\n")
+	b.WriteString("    local cur=\"${COMP_WORDS[COMP_CWORD]}\"\n")
+	b.WriteString("    if [[ \"$cur\" == \"=\" ]]; then\n")
+	b.WriteString("        cur=\"\"\n")
+	b.WriteString("    fi\n")
+	b.WriteString("    local node=" + shellQuote(cmdCfg.Name) + "\n")
+	b.WriteString("    local takesArg=0\n")
+	b.WriteString("    local i w\n")
+	b.WriteString("    for ((i = 1; i < COMP_CWORD; i++)); do\n")
+	b.WriteString("        w=\"${COMP_WORDS[i]}\"\n")
+	b.WriteString("        if [[ \"$w\" == \"=\" ]]; then\n")
+	b.WriteString("            continue\n")
+	b.WriteString("        fi\n")
+	b.WriteString("        if [[ $takesArg != 0 ]]; then\n")
+	b.WriteString("            takesArg=0\n")
+	b.WriteString("            continue\n")
+	b.WriteString("        fi\n")
+	b.WriteString("        case \"$node\" in\n")
+	for _, node := range nodes {
+		b.WriteString("        " + shellQuote(node.path) + ")\n")
+		b.WriteString("            case \"$w\" in\n")
+		for _, opt := range node.opts {
+			if opt.hasArg {
+				b.WriteString("            " + bashPattern(opt.names) + ") takesArg=" +
+					opt.takesArgMark() + " ;;\n")
+			}
+		}
+		for _, sub := range node.subCmds {
+			b.WriteString("            " + bashPattern(sub.names) + ") node=" +
+				shellQuote(sub.path) + " ;;\n")
+		}
+		b.WriteString("            esac\n")
+		b.WriteString("            ;;\n")
+	}
+	b.WriteString("        esac\n")
+	b.WriteString("    done\n\n")
+	b.WriteString("    if [[ $takesArg == 2 || \"$cur\" == -[!-]?* ]]; then\n")
+	b.WriteString("        local IFS=$'\\n'\n")
+	b.WriteString("        COMPREPLY=($(\"${COMP_WORDS[0]}\" " + completeArg +
+		" \"${COMP_WORDS[@]:1:COMP_CWORD-1}\" \"$cur\" 2>/dev/null))\n")
+	b.WriteString("        return 0\n")
+	b.WriteString("    fi\n")
+	b.WriteString("    if [[ $takesArg != 0 ]]; then\n")
+	b.WriteString("        COMPREPLY=($(compgen -f -- \"$cur\"))\n")
+	b.WriteString("        return 0\n")
+	b.WriteString("    fi\n\n")
+	b.WriteString("    case \"$node\" in\n")
+	for _, node := range nodes {
+		var optWords, subCmdWords []string
+		for _, opt := range node.opts {
+			optWords = append(optWords, opt.names...)
+		}
+		for _, sub := range node.subCmds {
+			subCmdWords = append(subCmdWords, sub.names...)
+		}
+		b.WriteString("    " + shellQuote(node.path) + ")\n")
+		b.WriteString("        if [[ \"$cur\" == -* ]]; then\n")
+		b.WriteString("            COMPREPLY=($(compgen -W " +
+			shellQuote(strings.Join(optWords, " ")) + " -- \"$cur\"))\n")
+		b.WriteString("        else\n")
+		if len(subCmdWords) > 0 {
+			b.WriteString("            COMPREPLY=($(compgen -W " +
+				shellQuote(strings.Join(subCmdWords, " ")) + " -- \"$cur\"))\n")
+		} else {
+			b.WriteString("            COMPREPLY=($(compgen -f -- \"$cur\"))\n")
+		}
+		b.WriteString("        fi\n")
+		b.WriteString("        ;;\n")
+	}
+	b.WriteString("    esac\n")
+	b.WriteString("}\n\n")
+	b.WriteString("complete -F " + fn + " " + cmdCfg.Name + "\n")
+
+	return b.String(), nil
+}
+
+func bashPattern(names []string) string {
+	quoted := make([]string, len(names))
+	for i, nm := range names {
+		quoted[i] = shellQuote(nm)
+	}
+	return strings.Join(quoted, "|")
+}
+
+// MakeZshCompletion is the function that creates a zsh completion script from a command
+// configuration.
+// The Name of the command configuration is used as the command name for which the completion is
+// registered.
+// The option names and the sub command names listed in the script are same with
+// MakeBashCompletion, and the Desc fields of OptCfg and CmdCfg are used as their descriptions.
+//
+// The created script can be loaded like: source <(app completion zsh), or can be put into a
+// directory in $fpath with the file name: _app.
+func MakeZshCompletion(cmdCfg CmdCfg) (string, error) {
+	nodes, err := newComplNodes(cmdCfg, cmdCfg.Name, nil)
+	if err != nil {
+		return "", err
+	}
+
+	fn := "_" + shellFuncName(cmdCfg.Name)
+
+	var b strings.Builder
+	b.WriteString("#compdef " + cmdCfg.Name + "\n")
+	b.WriteString("compdef " + fn + " " + cmdCfg.Name + "\n\n")
+	b.WriteString(fn + "() {\n")
+	b.WriteString("    local node=" + shellQuote(cmdCfg.Name) + "\n")
+	b.WriteString("    local takesArg=0\n")
+	b.WriteString("    local i w\n")
+	b.WriteString("    for ((i = 2; i < CURRENT; i++)); do\n")
+	b.WriteString("        w=\"${words[i]}\"\n")
+	b.WriteString("        if (( takesArg )); then\n")
+	b.WriteString("            takesArg=0\n")
+	b.WriteString("            continue\n")
+	b.WriteString("        fi\n")
+	b.WriteString("        case \"$node\" in\n")
+	for _, node := range nodes {
+		b.WriteString("        (" + shellQuote(node.path) + ")\n")
+		b.WriteString("            case \"$w\" in\n")
+		for _, opt := range node.opts {
+			if opt.hasArg {
+				b.WriteString("            (" + bashPattern(opt.names) + ") takesArg=" +
+					opt.takesArgMark() + " ;;\n")
+			}
+		}
+		for _, sub := range node.subCmds {
+			b.WriteString("            (" + bashPattern(sub.names) + ") node=" +
+				shellQuote(sub.path) + " ;;\n")
+		}
+		b.WriteString("            esac\n")
+		b.WriteString("            ;;\n")
+	}
+	b.WriteString("        esac\n")
+	b.WriteString("    done\n\n")
+	b.WriteString("    if (( takesArg == 2 )) || [[ \"${words[CURRENT]}\" == --*=* ]] ||\n")
+	b.WriteString("        [[ \"${words[CURRENT]}\" == -[^-]?* ]]; then\n")
+	b.WriteString("        local -a cands\n")
+	b.WriteString("        cands=(${(f)\"$(\"${words[1]}\" " + completeArg +
+		" \"${(@)words[2,CURRENT]}\" 2>/dev/null)\"})\n")
+	b.WriteString("        compadd -Q -- \"${cands[@]}\"\n")
+	b.WriteString("        return\n")
+	b.WriteString("    fi\n")
+	b.WriteString("    if (( takesArg )); then\n")
+	b.WriteString("        _files\n")
+	b.WriteString("        return\n")
+	b.WriteString("    fi\n\n")
+	b.WriteString("    local -a opts subcmds\n")
+	b.WriteString("    case \"$node\" in\n")
+	for _, node := range nodes {
+		b.WriteString("    (" + shellQuote(node.path) + ")\n")
+		b.WriteString("        opts=(")
+		sep := ""
+		for _, opt := range node.opts {
+			for _, nm := range opt.names {
+				b.WriteString(sep + shellQuote(zshDescribeItem(nm, opt.desc)))
+				sep = " "
+			}
+		}
+		b.WriteString(")\n")
+		b.WriteString("        subcmds=(")
+		sep = ""
+		for _, sub := range node.subCmds {
+			for _, nm := range sub.names {
+				b.WriteString(sep + shellQuote(zshDescribeItem(nm, sub.desc)))
+				sep = " "
+			}
+		}
+		b.WriteString(")\n")
+		b.WriteString("        ;;\n")
+	}
+	b.WriteString("    esac\n\n")
+	b.WriteString("    if [[ \"${words[CURRENT]}\" == -* ]]; then\n")
+	b.WriteString("        _describe -t options 'option' opts\n")
+	b.WriteString("    elif (( ${#subcmds} )); then\n")
+	b.WriteString("        _describe -t commands 'command' subcmds\n")
+	b.WriteString("    else\n")
+	b.WriteString("        _files\n")
+	b.WriteString("    fi\n")
+	b.WriteString("}\n\n")
+	b.WriteString("if [ \"$funcstack[1]\" = \"" + fn + "\" ]; then\n")
+	b.WriteString("    " + fn + " \"$@\"\n")
+	b.WriteString("fi\n")
+
+	return b.String(), nil
+}
+
+func zshDescribeItem(name, desc string) string {
+	name = strings.ReplaceAll(name, ":", `\:`)
+	if len(desc) == 0 {
+		return name
+	}
+	return name + ":" + desc
+}
+
+// MakeFishCompletion is the function that creates a fish completion script from a command
+// configuration.
+// The Name of the command configuration is used as the command name for which the completion is
+// registered.
+// The option names and the sub command names listed in the script are same with
+// MakeBashCompletion, and the Desc fields of OptCfg and CmdCfg are used as their descriptions.
+//
+// The created script can be loaded like: app completion fish | source, or can be put into
+// ~/.config/fish/completions/app.fish.
+func MakeFishCompletion(cmdCfg CmdCfg) (string, error) {
+	nodes, err := newComplNodes(cmdCfg, cmdCfg.Name, nil)
+	if err != nil {
+		return "", err
+	}
+
+	fn := "__" + shellFuncName(cmdCfg.Name) + "_complete"
+
+	var b strings.Builder
+	b.WriteString("# fish completion for " + cmdCfg.Name + "\n\n")
+	b.WriteString("function " + fn + "\n")
+	b.WriteString("    set -l words (commandline -opc)\n")
+	b.WriteString("    set -l cur (commandline -ct)\n")
+	b.WriteString("    set -l node " + shellQuote(cmdCfg.Name) + "\n")
+	b.WriteString("    set -l takes_arg 0\n")
+	b.WriteString("    for w in $words[2..-1]\n")
+	b.WriteString("        if test $takes_arg != 0\n")
+	b.WriteString("            set takes_arg 0\n")
+	b.WriteString("            continue\n")
+	b.WriteString("        end\n")
+	b.WriteString("        switch $node\n")
+	for _, node := range nodes {
+		b.WriteString("            case " + shellQuote(node.path) + "\n")
+		b.WriteString("                switch $w\n")
+		for _, opt := range node.opts {
+			if opt.hasArg {
+				b.WriteString("                    case " + fishPattern(opt.names) + "\n")
+				b.WriteString("                        set takes_arg " + opt.takesArgMark() + "\n")
+			}
+		}
+		for _, sub := range node.subCmds {
+			b.WriteString("                    case " + fishPattern(sub.names) + "\n")
+			b.WriteString("                        set node " + shellQuote(sub.path) + "\n")
+		}
+		b.WriteString("                end\n")
+	}
+	b.WriteString("        end\n")
+	b.WriteString("    end\n\n")
+	b.WriteString("    if test $takes_arg = 2; or string match -q -- '--*=*' \"$cur\"\n")
+	b.WriteString("        or string match -qr -- '^-[^-].' \"$cur\"\n")
+	b.WriteString("        command $words[1] " + completeArg +
+		" $words[2..-1] \"$cur\" 2>/dev/null\n")
+	b.WriteString("        return\n")
+	b.WriteString("    end\n")
+	b.WriteString("    if test $takes_arg != 0\n")
+	b.WriteString("        __fish_complete_path \"$cur\"\n")
+	b.WriteString("        return\n")
+	b.WriteString("    end\n\n")
+	b.WriteString("    switch $node\n")
+	for _, node := range nodes {
+		b.WriteString("        case " + shellQuote(node.path) + "\n")
+		b.WriteString("            if string match -q -- '-*' \"$cur\"\n")
+		for _, opt := range node.opts {
+			for _, nm := range opt.names {
+				b.WriteString("                printf '%s\\t%s\\n' " + shellQuote(nm) + " " +
+					shellQuote(opt.desc) + "\n")
+			}
+		}
+		if len(node.subCmds) > 0 {
+			b.WriteString("            else\n")
+			for _, sub := range node.subCmds {
+				for _, nm := range sub.names {
+					b.WriteString("                printf '%s\\t%s\\n' " + shellQuote(nm) + " " +
+						shellQuote(sub.desc) + "\n")
+				}
+			}
+		} else {
+			b.WriteString("            else\n")
+			b.WriteString("                __fish_complete_path \"$cur\"\n")
+		}
+		b.WriteString("            end\n")
+	}
+	b.WriteString("    end\n")
+	b.WriteString("end\n\n")
+	b.WriteString("complete -c " + cmdCfg.Name + " -f -a '(" + fn + ")'\n")
+
+	return b.String(), nil
+}
+
+func fishPattern(names []string) string {
+	quoted := make([]string, len(names))
+	for i, nm := range names {
+		quoted[i] = shellQuote(nm)
+	}
+	return strings.Join(quoted, " ")
+}
+
+// Complete is the method which writes completion candidates to the specified writer, one per
+// line, if the first command line argument is the reserved argument: __complete.
+// In this case, this method returns true, otherwise does nothing and returns false.
+//
+// The command line arguments following __complete are the words in the command line being
+// completed, excluding the command name, and the last of them is the word at the cursor.
+// If the word before it is an option which takes an argument and of which OptCfg has Completer,
+// or the word at the cursor is like --option=prefix or -oprefix, the candidates are the values
+// returned by the Completer which start with the prefix.
+// Otherwise the candidates are the option names or the sub command names of the command
+// configuration matched along the command tree.
+//
+// Cmd#Dispatch calls this method with os.Stdout before parsing command line arguments, so the
+// completion scripts created by MakeBashCompletion, MakeZshCompletion, and MakeFishCompletion
+// can ask a program using Cmd#Dispatch for the candidates of option arguments at runtime.
+// The parsing methods, like Cmd#ParseWith and Cmd#ParseFor, don't handle __complete, so a program
+// which doesn't use Cmd#Dispatch needs to call this method before parsing to support it.
+func (cmd *Cmd) Complete(cmdCfg CmdCfg, w io.Writer) (bool, error) {
+	if len(cmd._args) == 0 || cmd._args[0] != completeArg {
+		return false, nil
+	}
+
+	nodes, err := newComplNodes(cmdCfg, cmdCfg.Name, nil)
+	if err != nil {
+		return true, err
+	}
+
+	words := cmd._args[1:]
+	cur := ""
+	if len(words) > 0 {
+		cur = words[len(words)-1]
+		words = words[:len(words)-1]
+	}
+
+	for _, cand := range completeWords(nodes, words, cur) {
+		_, err := fmt.Fprintln(w, cand)
+		if err != nil {
+			return true, err
+		}
+	}
+	return true, nil
+}
+
+func completeWords(nodes []complNode, words []string, cur string) []string {
+	pathToNode := make(map[string]complNode, len(nodes))
+	for _, node := range nodes {
+		pathToNode[node.path] = node
+	}
+
+	node := nodes[0]
+	var optTakingArg *complOpt
+	isAfterEndOpt := false
+
+	for _, w := range words {
+		if w == "=" {
+			continue
+		}
+		if optTakingArg != nil {
+			optTakingArg = nil
+			continue
+		}
+		if isAfterEndOpt {
+			continue
+		}
+		if w == "--" {
+			isAfterEndOpt = true
+			continue
+		}
+		if strings.HasPrefix(w, "-") {
+			opt, ok := node.findOpt(w)
+			if ok && opt.hasArg {
+				optTakingArg = &opt
+			}
+			continue
+		}
+		for _, sub := range node.subCmds {
+			if contains(sub.names, w) {
+				node = pathToNode[sub.path]
+				break
+			}
+		}
+	}
+
+	if optTakingArg != nil {
+		return optTakingArg.complete(cur, "")
+	}
+	if isAfterEndOpt {
+		return nil
+	}
+
+	var cands []string
+	if strings.HasPrefix(cur, "-") {
+		i := strings.IndexByte(cur, '=')
+		if i >= 0 {
+			opt, ok := node.findOpt(cur[:i])
+			if !ok {
+				return nil
+			}
+			return opt.complete(cur[i+1:], cur[:i+1])
+		}
+		if !strings.HasPrefix(cur, "--") && len(cur) > 2 {
+			return node.completeAttachedArg(cur)
+		}
+		for _, opt := range node.opts {
+			for _, nm := range opt.names {
+				if strings.HasPrefix(nm, cur) {
+					cands = append(cands, nm)
+				}
+			}
+		}
+		return cands
+	}
+
+	for _, sub := range node.subCmds {
+		for _, nm := range sub.names {
+			if strings.HasPrefix(nm, cur) {
+				cands = append(cands, nm)
+			}
+		}
+	}
+	return cands
+}
+
+func contains(arr []string, s string) bool {
+	for _, elem := range arr {
+		if elem == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,305 @@
+package cliargs_test
+
+import (
+	"bytes"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sttk/cliargs"
+	"github.com/sttk/cliargs/errors"
+)
+
+func newTestComplCmdCfg() cliargs.CmdCfg {
+	completeProfile := func(prefix string) []string {
+		return []string{"dev", "default", "prod"}
+	}
+
+	return cliargs.CmdCfg{
+		Name: "app",
+		OptCfgs: []cliargs.OptCfg{
+			cliargs.OptCfg{Names: []string{"verbose", "v"}, Desc: "Prints logs.\nMore lines."},
+			cliargs.OptCfg{Names: []string{"config"}, HasArg: true, Desc: "Config file."},
+			cliargs.OptCfg{
+				Names:     []string{"profile", "p"},
+				HasArg:    true,
+				Completer: &completeProfile,
+			},
+			cliargs.OptCfg{Names: []string{"*"}},
+		},
+		SubCmds: []cliargs.CmdCfg{
+			cliargs.CmdCfg{
+				Name:    "remote",
+				Aliases: []string{"r"},
+				Desc:    "Manages remotes.",
+				SubCmds: []cliargs.CmdCfg{
+					cliargs.CmdCfg{
+						Name: "add",
+						Desc: "Adds a remote.",
+						OptCfgs: []cliargs.OptCfg{
+							cliargs.OptCfg{Names: []string{"track", "t"}, HasArg: true},
+						},
+					},
+					cliargs.CmdCfg{Name: "remove", Desc: "Removes a remote's entry."},
+				},
+			},
+			cliargs.CmdCfg{
+				Name: "status",
+				OptStore: &struct {
+					Short bool `optcfg:"short,s" optdesc:"Shows short format."`
+				}{},
+			},
+		},
+	}
+}
+
+func runBashCompletion(t *testing.T, script string, words ...string) []string {
+	bash, err := exec.LookPath("bash")
+	if err != nil {
+		t.Skip("bash is not found")
+	}
+
+	quoted := make([]string, len(words))
+	for i, w := range words {
+		quoted[i] = "'" + w + "'"
+	}
+
+	src := script + "\n" +
+		"COMP_WORDS=(" + strings.Join(quoted, " ") + ")\n" +
+		"COMP_CWORD=$((${#COMP_WORDS[@]} - 1))\n" +
+		"app() { printf '[%s]\\n' \"$@\"; }\n" +
+		"_app_completion\n" +
+		"printf '%s\\n' \"${COMPREPLY[@]}\"\n"
+
+	out, err := exec.Command(bash, "-c", src).Output()
+	assert.Nil(t, err)
+	return strings.Split(strings.TrimSpace(string(out)), "\n")
+}
+
+func TestMakeBashCompletion(t *testing.T) {
+	script, err := cliargs.MakeBashCompletion(newTestComplCmdCfg())
+	assert.Nil(t, err)
+	assert.True(t, strings.HasPrefix(script, "# bash completion for app\n"))
+	assert.True(t, strings.HasSuffix(script, "complete -F _app_completion app\n"))
+	assert.False(t, strings.Contains(script, "'--*'"))
+}
+
+func TestMakeBashCompletion_run(t *testing.T) {
+	script, err := cliargs.MakeBashCompletion(newTestComplCmdCfg())
+	assert.Nil(t, err)
+
+	assert.Equal(t, runBashCompletion(t, script, "app", "-"),
+		[]string{"--verbose", "-v", "--config", "--profile", "-p"})
+	assert.Equal(t, runBashCompletion(t, script, "app", ""),
+		[]string{"remote", "r", "status"})
+	assert.Equal(t, runBashCompletion(t, script, "app", "re"),
+		[]string{"remote"})
+	assert.Equal(t, runBashCompletion(t, script, "app", "-v", "r", ""),
+		[]string{"add", "remove"})
+	assert.Equal(t, runBashCompletion(t, script, "app", "remote", "add", "-"),
+		[]string{"--track", "-t"})
+	assert.Equal(t, runBashCompletion(t, script, "app", "status", "--"),
+		[]string{"--short"})
+}
+
+func TestMakeBashCompletion_run_skipsOptArg(t *testing.T) {
+	script, err := cliargs.MakeBashCompletion(newTestComplCmdCfg())
+	assert.Nil(t, err)
+
+	assert.Equal(t, runBashCompletion(t, script, "app", "--config", "remote", ""),
+		[]string{"remote", "r", "status"})
+	assert.Equal(t, runBashCompletion(t, script, "app", "--config", "=", "remote", ""),
+		[]string{"remote", "r", "status"})
+	assert.Equal(t, runBashCompletion(t, script, "app", "--config", "no-such-file-"),
+		[]string{""})
+}
+
+func TestMakeBashCompletion_run_callsProgram(t *testing.T) {
+	script, err := cliargs.MakeBashCompletion(newTestComplCmdCfg())
+	assert.Nil(t, err)
+
+	assert.Equal(t, runBashCompletion(t, script, "app", "-v", "--profile", "d"),
+		[]string{"[__complete]", "[-v]", "[--profile]", "[d]"})
+	assert.Equal(t, runBashCompletion(t, script, "app", "--profile", "=", "d"),
+		[]string{"[__complete]", "[--profile]", "[=]", "[d]"})
+	assert.Equal(t, runBashCompletion(t, script, "app", "--profile", "="),
+		[]string{"[__complete]", "[--profile]", "[]"})
+	assert.Equal(t, runBashCompletion(t, script, "app", "-v", "-pd"),
+		[]string{"[__complete]", "[-v]", "[-pd]"})
+}
+
+func TestMakeBashCompletion_syntax(t *testing.T) {
+	bash, err := exec.LookPath("bash")
+	if err != nil {
+		t.Skip("bash is not found")
+	}
+
+	script, err := cliargs.MakeBashCompletion(newTestComplCmdCfg())
+	assert.Nil(t, err)
+
+	cmd := exec.Command(bash, "-n")
+	cmd.Stdin = strings.NewReader(script)
+	assert.Nil(t, cmd.Run())
+}
+
+func TestMakeZshCompletion(t *testing.T) {
+	script, err := cliargs.MakeZshCompletion(newTestComplCmdCfg())
+	assert.Nil(t, err)
+	assert.True(t, strings.HasPrefix(script, "#compdef app\ncompdef _app app\n"))
+	assert.True(t, strings.Contains(script,
+		"        opts=('--verbose:Prints logs.' '-v:Prints logs.' '--config:Config file.' "+
+			"'--profile' '-p')\n"))
+	assert.True(t, strings.Contains(script,
+		"        subcmds=('remote:Manages remotes.' 'r:Manages remotes.' 'status')\n"))
+	assert.True(t, strings.Contains(script,
+		"        subcmds=('add:Adds a remote.' 'remove:Removes a remote'\\''s entry.')\n"))
+	assert.True(t, strings.Contains(script, "            ('--config') takesArg=1 ;;\n"))
+	assert.True(t, strings.Contains(script, "            ('--profile'|'-p') takesArg=2 ;;\n"))
+	assert.True(t, strings.Contains(script,
+		"            ('remote'|'r') node='app remote' ;;\n"))
+	assert.True(t, strings.Contains(script,
+		"        opts=('--short:Shows short format.' '-s:Shows short format.')\n"))
+}
+
+func TestMakeFishCompletion(t *testing.T) {
+	script, err := cliargs.MakeFishCompletion(newTestComplCmdCfg())
+	assert.Nil(t, err)
+	assert.True(t, strings.HasPrefix(script, "# fish completion for app\n"))
+	assert.True(t, strings.HasSuffix(script, "complete -c app -f -a '(__app_complete)'\n"))
+	assert.True(t, strings.Contains(script,
+		"                printf '%s\\t%s\\n' '--verbose' 'Prints logs.'\n"))
+	assert.True(t, strings.Contains(script,
+		"                printf '%s\\t%s\\n' 'r' 'Manages remotes.'\n"))
+	assert.True(t, strings.Contains(script,
+		"                    case 'remote' 'r'\n"+
+			"                        set node 'app remote'\n"))
+	assert.True(t, strings.Contains(script,
+		"                    case '--track' '-t'\n"+
+			"                        set takes_arg 1\n"))
+	assert.True(t, strings.Contains(script,
+		"                    case '--profile' '-p'\n"+
+			"                        set takes_arg 2\n"))
+	assert.True(t, strings.Contains(script,
+		"        command $words[1] __complete $words[2..-1] \"$cur\" 2>/dev/null\n"))
+}
+
+func TestMakeCompletion_optStoreIsInvalid(t *testing.T) {
+	cmdCfg := cliargs.CmdCfg{
+		Name: "app",
+		OptStore: struct {
+			Foo bool `optcfg:"foo"`
+		}{},
+	}
+
+	_, err := cliargs.MakeBashCompletion(cmdCfg)
+	assert.IsType(t, errors.OptionStoreIsNotChangeable{}, err)
+
+	_, err = cliargs.MakeZshCompletion(cmdCfg)
+	assert.IsType(t, errors.OptionStoreIsNotChangeable{}, err)
+
+	_, err = cliargs.MakeFishCompletion(cmdCfg)
+	assert.IsType(t, errors.OptionStoreIsNotChangeable{}, err)
+}
+
+func TestCmd_Complete(t *testing.T) {
+	cmdCfg := newTestComplCmdCfg()
+
+	var complete = func(args ...string) (bool, string) {
+		cmd := cliargs.NewCmdFromArgs("app", args)
+		var buf bytes.Buffer
+		ok, err := cmd.Complete(cmdCfg, &buf)
+		assert.Nil(t, err)
+		return ok, buf.String()
+	}
+
+	ok, out := complete("--profile", "d")
+	assert.False(t, ok)
+	assert.Equal(t, out, "")
+
+	ok, out = complete("__complete", "--profile", "d")
+	assert.True(t, ok)
+	assert.Equal(t, out, "dev\ndefault\n")
+
+	ok, out = complete("__complete", "-v", "-p", "=", "")
+	assert.True(t, ok)
+	assert.Equal(t, out, "dev\ndefault\nprod\n")
+
+	ok, out = complete("__complete", "--profile=pr")
+	assert.True(t, ok)
+	assert.Equal(t, out, "--profile=prod\n")
+
+	ok, out = complete("__complete", "--config", "")
+	assert.True(t, ok)
+	assert.Equal(t, out, "")
+
+	ok, out = complete("__complete", "--config=")
+	assert.True(t, ok)
+	assert.Equal(t, out, "")
+
+	ok, out = complete("__complete", "-pd")
+	assert.True(t, ok)
+	assert.Equal(t, out, "-pdev\n-pdefault\n")
+
+	ok, out = complete("__complete", "-vppr")
+	assert.True(t, ok)
+	assert.Equal(t, out, "-vpprod\n")
+
+	ok, out = complete("__complete", "-vv")
+	assert.True(t, ok)
+	assert.Equal(t, out, "")
+
+	ok, out = complete("__complete", "-xd")
+	assert.True(t, ok)
+	assert.Equal(t, out, "")
+
+	ok, out = complete("__complete", "--pro")
+	assert.True(t, ok)
+	assert.Equal(t, out, "--profile\n")
+
+	ok, out = complete("__complete", "--config", "remote", "r")
+	assert.True(t, ok)
+	assert.Equal(t, out, "remote\nr\n")
+
+	ok, out = complete("__complete", "r", "add", "-")
+	assert.True(t, ok)
+	assert.Equal(t, out, "--track\n-t\n")
+
+	ok, out = complete("__complete", "--", "-")
+	assert.True(t, ok)
+	assert.Equal(t, out, "")
+
+	ok, out = complete("__complete")
+	assert.True(t, ok)
+	assert.Equal(t, out, "remote\nr\nstatus\n")
+}
+
+func TestCmd_Complete_optStoreIsInvalid(t *testing.T) {
+	cmdCfg := cliargs.CmdCfg{
+		Name:     "app",
+		OptStore: struct{}{},
+	}
+
+	cmd := cliargs.NewCmdFromArgs("app", []string{"__complete", ""})
+	var buf bytes.Buffer
+	ok, err := cmd.Complete(cmdCfg, &buf)
+	assert.True(t, ok)
+	assert.IsType(t, errors.OptionStoreIsNotChangeable{}, err)
+}
+
+func TestDispatch_complete(t *testing.T) {
+	isRun := false
+	cmdCfg := cliargs.CmdCfg{
+		Name: "app",
+		Run: func(cmd cliargs.Cmd, parents []cliargs.Cmd) error {
+			isRun = true
+			return nil
+		},
+	}
+
+	cmd := cliargs.NewCmdFromArgs("app", []string{"__complete", "--no-such-option"})
+	err := cmd.Dispatch(cmdCfg)
+	assert.Nil(t, err)
+	assert.False(t, isRun)
+}
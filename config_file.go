@@ -0,0 +1,331 @@
+// Copyright (C) 2026 Takayuki Sato. All Rights Reserved.
+// This program is free software under MIT License.
+// See the file LICENSE in this distribution for more details.
+
+package cliargs
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/sttk/cliargs/errors"
+)
+
+// parseConfigFile is the function that parses the content of a configuration file and returns a
+// map of which keys are store keys and values are option arguments.
+// The format of the file is determined by its extension: .json is JSON, .yaml and .yml are YAML
+// (a subset of), and .ini, .toml, .conf, and .cfg are TOML-like INI.
+// Keys in nested tables, sections, or mappings are joined with dots, like "Parent.Child".
+func parseConfigFile(path string, data []byte) (map[string][]string, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return parseJsonConfig(path, data)
+	case ".yaml", ".yml":
+		return parseYamlConfig(path, data)
+	case ".ini", ".toml", ".conf", ".cfg":
+		return parseIniConfig(path, data)
+	default:
+		return nil, errors.ConfigFileIsInvalid{
+			File: path, Cause: fmt.Errorf("unsupported file format")}
+	}
+}
+
+func parseJsonConfig(path string, data []byte) (map[string][]string, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	var obj map[string]any
+	e := dec.Decode(&obj)
+	if e != nil {
+		return nil, errors.ConfigFileIsInvalid{File: path, Cause: e}
+	}
+
+	m := make(map[string][]string)
+	e = flattenJsonObject(obj, "", m)
+	if e != nil {
+		return nil, errors.ConfigFileIsInvalid{File: path, Cause: e}
+	}
+	return m, nil
+}
+
+func flattenJsonObject(obj map[string]any, prefix string, m map[string][]string) error {
+	for k, v := range obj {
+		key := prefix + k
+		switch val := v.(type) {
+		case nil:
+		case map[string]any:
+			e := flattenJsonObject(val, key+".", m)
+			if e != nil {
+				return e
+			}
+		case []any:
+			arr := make([]string, 0, len(val))
+			for _, elem := range val {
+				s, ok := jsonScalarToString(elem)
+				if !ok {
+					return fmt.Errorf("array element of %q is not a scalar", key)
+				}
+				arr = append(arr, s)
+			}
+			m[key] = arr
+		default:
+			s, _ := jsonScalarToString(val)
+			m[key] = []string{s}
+		}
+	}
+	return nil
+}
+
+func jsonScalarToString(v any) (string, bool) {
+	switch val := v.(type) {
+	case string:
+		return val, true
+	case json.Number:
+		return val.String(), true
+	case bool:
+		return strconv.FormatBool(val), true
+	default:
+		return "", false
+	}
+}
+
+func parseIniConfig(path string, data []byte) (map[string][]string, error) {
+	m := make(map[string][]string)
+	prefix := ""
+
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	lineNo := 0
+	for sc.Scan() {
+		lineNo++
+		line := strings.TrimSpace(sc.Text())
+		if len(line) == 0 || line[0] == '#' || line[0] == ';' {
+			continue
+		}
+
+		if line[0] == '[' {
+			line = stripComment(line)
+			if !strings.HasSuffix(line, "]") || len(line) < 3 {
+				return nil, errors.ConfigFileIsInvalid{
+					File: path, Line: lineNo, Cause: fmt.Errorf("bad section header")}
+			}
+			prefix = strings.TrimSpace(line[1:len(line)-1]) + "."
+			continue
+		}
+
+		i := strings.IndexByte(line, '=')
+		if i <= 0 {
+			return nil, errors.ConfigFileIsInvalid{
+				File: path, Line: lineNo, Cause: fmt.Errorf("no \"=\" after key")}
+		}
+
+		key := unquoteKey(strings.TrimSpace(line[:i]))
+		arr, e := parseConfigValue(strings.TrimSpace(line[i+1:]))
+		if e != nil {
+			return nil, errors.ConfigFileIsInvalid{File: path, Line: lineNo, Cause: e}
+		}
+		m[prefix+key] = arr
+	}
+
+	return m, nil
+}
+
+type yamlLevel struct {
+	indent int
+	prefix string
+}
+
+func parseYamlConfig(path string, data []byte) (map[string][]string, error) {
+	m := make(map[string][]string)
+	levels := []yamlLevel{yamlLevel{indent: -1}}
+	listKey := ""
+	listIndent := -1
+
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	lineNo := 0
+	for sc.Scan() {
+		lineNo++
+		text := sc.Text()
+		line := strings.TrimSpace(text)
+		if len(line) == 0 || line[0] == '#' || line == "---" {
+			continue
+		}
+		indent := len(text) - len(strings.TrimLeft(text, " "))
+
+		if line == "-" || strings.HasPrefix(line, "- ") {
+			if len(listKey) == 0 || indent < listIndent {
+				return nil, errors.ConfigFileIsInvalid{
+					File: path, Line: lineNo, Cause: fmt.Errorf("sequence item without key")}
+			}
+			item, e := parseConfigValue(strings.TrimSpace(line[1:]))
+			if e != nil {
+				return nil, errors.ConfigFileIsInvalid{File: path, Line: lineNo, Cause: e}
+			}
+			if len(item) != 1 {
+				return nil, errors.ConfigFileIsInvalid{
+					File: path, Line: lineNo, Cause: fmt.Errorf("sequence item is not a scalar")}
+			}
+			m[listKey] = append(m[listKey], item[0])
+			continue
+		}
+
+		for indent <= levels[len(levels)-1].indent {
+			levels = levels[:len(levels)-1]
+		}
+		prefix := levels[len(levels)-1].prefix
+		listKey = ""
+
+		i := yamlKeyEnd(line)
+		if i <= 0 {
+			return nil, errors.ConfigFileIsInvalid{
+				File: path, Line: lineNo, Cause: fmt.Errorf("no \":\" after key")}
+		}
+
+		key := prefix + unquoteKey(strings.TrimSpace(line[:i]))
+		val := strings.TrimSpace(stripComment(line[i+1:]))
+		if len(val) == 0 {
+			levels = append(levels, yamlLevel{indent: indent, prefix: key + "."})
+			listKey = key
+			listIndent = indent
+			continue
+		}
+
+		arr, e := parseConfigValue(val)
+		if e != nil {
+			return nil, errors.ConfigFileIsInvalid{File: path, Line: lineNo, Cause: e}
+		}
+		m[key] = arr
+	}
+
+	return m, nil
+}
+
+func yamlKeyEnd(line string) int {
+	if len(line) > 0 && (line[0] == '"' || line[0] == '\'') {
+		j := strings.IndexByte(line[1:], line[0])
+		if j < 0 {
+			return -1
+		}
+		k := strings.IndexByte(line[j+2:], ':')
+		if k < 0 {
+			return -1
+		}
+		return j + 2 + k
+	}
+	for i := 0; i < len(line); i++ {
+		if line[i] == ':' && (i == len(line)-1 || line[i+1] == ' ') {
+			return i
+		}
+	}
+	return -1
+}
+
+func unquoteKey(key string) string {
+	n := len(key)
+	if n > 1 && (key[0] == '"' || key[0] == '\'') && key[n-1] == key[0] {
+		return key[1 : n-1]
+	}
+	return key
+}
+
+// stripComment is the function that removes a comment starting with "#" which is not in a quoted
+// string.
+func stripComment(s string) string {
+	var quote byte
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case quote != 0:
+			if c == '\\' && quote == '"' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '#' && (i == 0 || s[i-1] == ' ' || s[i-1] == '\t'):
+			return strings.TrimSpace(s[:i])
+		}
+	}
+	return s
+}
+
+// parseConfigValue is the function that parses a value in a configuration file.
+// A value is a quoted string, a bare string, or an array of them rounded by square brackets.
+func parseConfigValue(s string) ([]string, error) {
+	s = stripComment(s)
+	if len(s) == 0 {
+		return []string{""}, nil
+	}
+
+	if s[0] != '[' {
+		v, rest, e := parseConfigScalar(s)
+		if e != nil {
+			return nil, e
+		}
+		if len(strings.TrimSpace(rest)) > 0 {
+			return nil, fmt.Errorf("unexpected characters after value: %s", rest)
+		}
+		return []string{v}, nil
+	}
+
+	if s[len(s)-1] != ']' {
+		return nil, fmt.Errorf("array is not closed")
+	}
+
+	arr := []string{}
+	rest := strings.TrimSpace(s[1 : len(s)-1])
+	for len(rest) > 0 {
+		var v string
+		var e error
+		if rest[0] == '"' || rest[0] == '\'' {
+			v, rest, e = parseConfigScalar(rest)
+			if e != nil {
+				return nil, e
+			}
+			rest = strings.TrimSpace(rest)
+			if len(rest) > 0 {
+				if rest[0] != ',' {
+					return nil, fmt.Errorf("no \",\" between array elements")
+				}
+				rest = strings.TrimSpace(rest[1:])
+			}
+		} else {
+			i := strings.IndexByte(rest, ',')
+			if i < 0 {
+				v, rest = strings.TrimSpace(rest), ""
+			} else {
+				v, rest = strings.TrimSpace(rest[:i]), strings.TrimSpace(rest[i+1:])
+			}
+		}
+		arr = append(arr, v)
+	}
+	return arr, nil
+}
+
+func parseConfigScalar(s string) (string, string, error) {
+	switch s[0] {
+	case '"':
+		for i := 1; i < len(s); i++ {
+			if s[i] == '\\' {
+				i++
+			} else if s[i] == '"' {
+				v, e := strconv.Unquote(s[:i+1])
+				return v, s[i+1:], e
+			}
+		}
+		return "", "", fmt.Errorf("string is not closed")
+	case '\'':
+		i := strings.IndexByte(s[1:], '\'')
+		if i < 0 {
+			return "", "", fmt.Errorf("string is not closed")
+		}
+		return s[1 : i+1], s[i+2:], nil
+	default:
+		return strings.TrimSpace(s), "", nil
+	}
+}
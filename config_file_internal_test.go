@@ -0,0 +1,158 @@
+package cliargs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/sttk/cliargs/errors"
+)
+
+func TestParseConfigFile_json(t *testing.T) {
+	data := []byte(`{
+  "foo-bar": "abc",
+  "baz": 123,
+  "qux": 1.5e3,
+  "quux": true,
+  "corge": [1, "two", false],
+  "grault": null,
+  "garply": { "waldo": "x", "fred": { "plugh": [] } }
+}`)
+	m, err := parseConfigFile("path/to/config.json", data)
+	assert.Nil(t, err)
+	assert.Equal(t, m, map[string][]string{
+		"foo-bar":           []string{"abc"},
+		"baz":               []string{"123"},
+		"qux":               []string{"1.5e3"},
+		"quux":              []string{"true"},
+		"corge":             []string{"1", "two", "false"},
+		"garply.waldo":      []string{"x"},
+		"garply.fred.plugh": []string{},
+	})
+}
+
+func TestParseConfigFile_jsonIsInvalid(t *testing.T) {
+	_, err := parseConfigFile("config.json", []byte(`{"foo": }`))
+	switch e := err.(type) {
+	case errors.ConfigFileIsInvalid:
+		assert.Equal(t, e.File, "config.json")
+		assert.NotNil(t, e.Cause)
+	default:
+		assert.Fail(t, err.Error())
+	}
+
+	_, err = parseConfigFile("config.json", []byte(`{"foo": [[1]]}`))
+	assert.Equal(t, err.Error(),
+		"ConfigFileIsInvalid{File:config.json,Line:0,Cause:array element of \"foo\" is not a scalar}")
+}
+
+func TestParseConfigFile_ini(t *testing.T) {
+	data := []byte(`# comment
+; comment
+foo-bar = abc def
+baz = 123  # comment
+qux = "a \"b\" # c"
+quux = 'd\e'
+corge = [1, "two, three", 'four']
+grault =
+
+[garply]
+waldo = x
+"fred" = [ ]
+`)
+	m, err := parseConfigFile("path/to/config.toml", data)
+	assert.Nil(t, err)
+	assert.Equal(t, m, map[string][]string{
+		"foo-bar":      []string{"abc def"},
+		"baz":          []string{"123"},
+		"qux":          []string{"a \"b\" # c"},
+		"quux":         []string{"d\\e"},
+		"corge":        []string{"1", "two, three", "four"},
+		"grault":       []string{""},
+		"garply.waldo": []string{"x"},
+		"garply.fred":  []string{},
+	})
+
+	m, err = parseConfigFile("config.INI", []byte("foo=1\n"))
+	assert.Nil(t, err)
+	assert.Equal(t, m, map[string][]string{"foo": []string{"1"}})
+}
+
+func TestParseConfigFile_iniIsInvalid(t *testing.T) {
+	_, err := parseConfigFile("config.ini", []byte("foo = 1\nbar\n"))
+	assert.Equal(t, err.Error(),
+		"ConfigFileIsInvalid{File:config.ini,Line:2,Cause:no \"=\" after key}")
+
+	_, err = parseConfigFile("config.ini", []byte("[foo\n"))
+	assert.Equal(t, err.Error(),
+		"ConfigFileIsInvalid{File:config.ini,Line:1,Cause:bad section header}")
+
+	_, err = parseConfigFile("config.ini", []byte("foo = \"abc\n"))
+	assert.Equal(t, err.Error(),
+		"ConfigFileIsInvalid{File:config.ini,Line:1,Cause:string is not closed}")
+
+	_, err = parseConfigFile("config.ini", []byte("foo = [1, 2\n"))
+	assert.Equal(t, err.Error(),
+		"ConfigFileIsInvalid{File:config.ini,Line:1,Cause:array is not closed}")
+
+	_, err = parseConfigFile("config.ini", []byte("foo = \"a\" b\n"))
+	assert.Equal(t, err.Error(),
+		"ConfigFileIsInvalid{File:config.ini,Line:1,Cause:unexpected characters after value:  b}")
+}
+
+func TestParseConfigFile_yaml(t *testing.T) {
+	data := []byte(`---
+# comment
+foo-bar: abc def
+baz: 123 # comment
+url: http://example.com:8080/a#b
+qux: "a: b # c"
+quux: 'd'
+corge: [1, "two", three]
+grault:
+  - 1
+  - "two"
+- three
+garply:
+  waldo: x
+  fred:
+    plugh: y
+  xyzzy:
+  - z
+thud: end
+`)
+	m, err := parseConfigFile("path/to/config.yml", data)
+	assert.Nil(t, err)
+	assert.Equal(t, m, map[string][]string{
+		"foo-bar":           []string{"abc def"},
+		"baz":               []string{"123"},
+		"url":               []string{"http://example.com:8080/a#b"},
+		"qux":               []string{"a: b # c"},
+		"quux":              []string{"d"},
+		"corge":             []string{"1", "two", "three"},
+		"grault":            []string{"1", "two", "three"},
+		"garply.waldo":      []string{"x"},
+		"garply.fred.plugh": []string{"y"},
+		"garply.xyzzy":      []string{"z"},
+		"thud":              []string{"end"},
+	})
+}
+
+func TestParseConfigFile_yamlIsInvalid(t *testing.T) {
+	_, err := parseConfigFile("config.yaml", []byte("- foo\n"))
+	assert.Equal(t, err.Error(),
+		"ConfigFileIsInvalid{File:config.yaml,Line:1,Cause:sequence item without key}")
+
+	_, err = parseConfigFile("config.yaml", []byte("foo: 1\nbar\n"))
+	assert.Equal(t, err.Error(),
+		"ConfigFileIsInvalid{File:config.yaml,Line:2,Cause:no \":\" after key}")
+
+	_, err = parseConfigFile("config.yaml", []byte("foo:\n  - [1, 2]\n"))
+	assert.Equal(t, err.Error(),
+		"ConfigFileIsInvalid{File:config.yaml,Line:2,Cause:sequence item is not a scalar}")
+}
+
+func TestParseConfigFile_unsupportedFormat(t *testing.T) {
+	_, err := parseConfigFile("config.xml", []byte("<foo/>"))
+	assert.Equal(t, err.Error(),
+		"ConfigFileIsInvalid{File:config.xml,Line:0,Cause:unsupported file format}")
+}
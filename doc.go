@@ -38,6 +38,16 @@ All command line arguments after `--` are command arguments, even they starts wi
 	cmd.OptArgs("y")        // []
 	cmd.OptArgs("z")        // [2 3]
 
+To parse arguments which are not obtained from os.Args, for example, an input line of a REPL or
+fixtures in tests, create a Cmd instance with NewCmdFromArgs instead of NewCmd.
+And Cmd#ResetArgs replaces the arguments of a Cmd instance so that it can be parsed again.
+
+	cmd := cliargs.NewCmdFromArgs("app", []string{"--foo-bar", "hoge"})
+	err := cmd.Parse()
+
+	cmd.ResetArgs([]string{"--baz", "fuga"})
+	err = cmd.Parse()
+
 # Parses with configurations
 
 The Cmd struct has the method ParseWith which parses command line arguments with configurations.
@@ -45,8 +55,8 @@ The Cmd struct has the method ParseWith which parses command line arguments with
 This method takes an array of option configurations: OptCfg, and divides command line arguments to
 options and command arguments according to this configurations.
 
-An option configuration has fields: StoreKey, Names, HasArg, IsArray, Defaults, Desc, ArgInHelp,
-and Validator.
+An option configuration has fields: StoreKey, Names, HasArg, IsArray, Defaults, EnvVars, Desc,
+ArgInHelp, and Validator.
 
 StoreKey field is specified the key name to store the option value to the option map in the Cmd
 instance.
@@ -61,17 +71,86 @@ and Names fields as follows:
 OptCfg{StoreKey: "foo-bar", Names: []string{"f", "foo-bar"}}.
 
 HasArg field indicates the option requires one or more values.
+A short option of which HasArg is true can also take its value attached directly, like -n5 or
+-Iinclude.
+The argument following such an option is taken as its value even if it starts with "-", like
+--offset -3.
+A short option of which name is a digit, like -9, is allowed only if such a name is in Names.
+Since all digits are then parsed as short option names, a negative number as a command argument,
+like -5, needs to be placed after --.
 IsArray field indicates the option can have multiple values.
+IsMap field indicates the option can have multiple values of the form of key=value, like
+--label env=prod, which can be retrieved as a map with Cmd#OptMap, and RejectsDupKey field makes
+a key given multiple times an error instead of overwriting the previous value.
 Defaults field is an array of string which is used as default one or more option arguments if the
 option is not specified.
+EnvVars field is an array of environment variable names which are looked up before Defaults if the
+option is not specified.
+Required field indicates the option must be specified, and if it is not specified nor filled by
+EnvVars or Defaults, the parsing fails with MissingRequiredOption error.
+ExclusiveGroup field is a group name of mutually exclusive options, and the parsing fails with
+OptionsAreExclusive error if two or more options in the same group are specified, or with
+OptionGroupIsMissing error if none is specified though an option in the group is Required.
+Requires field is an array of the names of other options which must be present when the option is
+specified, and the parsing fails with OptionRequiresOthers error if not.
+IsHelp and IsVersion fields indicate the option requests help text or a version, and if such an
+option is given, the parsing fails only with HelpIsRequested or VersionIsRequested error, skipping
+any other errors, so that the caller can print help text or a version and exit normally.
 Desc is a description of the option for help text.
 ArgInHelp field is a text which is output after option name and aliases as an option value in help
 text.
+DefaultInHelp field is a text of the default value which is output after the description in help
+text, like "(default: info)", and is not used as an option argument.
+Group field is a name of the section in which the option is shown in help text.
+
+IsConfigFile field indicates the option arguments are paths of configuration files.
+The values in the configuration files (JSON, TOML-like INI, or a subset of YAML) are used as the
+option arguments of the options of which StoreKey matches the keys in the files.
+The precedence order of option arguments is: command line > environment variables >
+configuration files > Defaults.
 
 Validator field is to set a function pointer which validates an option argument.
 This module provides several validators that validate whether an option argument is in a valid
 numeric format.
 
+The parsing methods return only the first error by default.
+If Cmd#SetCollectsAllErrs is called with true before parsing, they return an ErrorList error
+which holds all errors occurred during parsing, including errors from validators.
+errors.Is and errors.As apply to each error in an ErrorList.
+
+The Error method of each error returns a text for debugging, like OptionNeedsArg{Option:foo,...}.
+To show an error to end users, errors.Message renders a human-readable message, like
+"option '--foo' requires an argument".
+The message templates can be overridden per error type and per language with errors.SetMessage,
+and the language is selected with errors.SetLang or errors.MessageIn.
+UnconfiguredOption and UnconfiguredSubCmd errors have the configured names which are similar to
+the mistyped name, which are retrieved with their Suggestions methods, and their messages include
+them, like "unknown option '--verbsoe' (did you mean '--verbose'?)".
+An error with suggestions is not equal to the one without them with ==, so use errors.Is instead,
+which compares only the option name or the sub command name.
+For a sub command returned by Cmd#ParseUntilSubCmdWith, UnconfiguredSubCmdOf creates such an
+error with the names of the supported sub commands.
+
+The command arguments can be checked with an array of ArgCfg set to Cmd#ArgCfgs before parsing.
+An ArgCfg has fields: Name, Min, Max, Validator, and Desc, and the command arguments are assigned
+to the ArgCfg(s) in order.
+Min and Max are the numbers of command arguments which the ArgCfg takes, and a negative Max means
+unlimited, which is for the variadic tail.
+If the zero values of both are set, the ArgCfg takes one optional command argument.
+The parsing fails with MissingCmdArg error if command arguments are fewer than required, with
+TooManyCmdArgs error if they are more than the ArgCfg(s) can take, and with CmdArgIsInvalid error
+if the Validator invalidates a command argument.
+The command arguments assigned to an ArgCfg can be retrieved with Cmd#CmdArg or Cmd#CmdArgs.
+
+	cmd := cliargs.NewCmdFromArgs("app", []string{"a.txt", "b.txt", "out"})
+	cmd.ArgCfgs = []cliargs.ArgCfg{
+	    cliargs.ArgCfg{Name: "src", Min: 1, Max: -1, Desc: "Source files."},
+	    cliargs.ArgCfg{Name: "dest", Min: 1, Desc: "Destination directory."},
+	}
+	err := cmd.ParseWith(optCfgs)
+	cmd.CmdArgs("src")      // [a.txt b.txt]
+	cmd.CmdArg("dest")      // out
+
 In addition,the help printing for an array of OptCfg is generated with Help.
 
 	// os.Args = []string{"app", "--foo-bar", "hoge", "--baz", "1", "-z=2", "-x" "fuga"}
@@ -120,6 +199,32 @@ In addition,the help printing for an array of OptCfg is generated with Help.
 	//   --foo-bar, -f     This is description of foo-bar.
 	//   --baz, -z <text>  This is description of baz.
 
+Help#AddArgs adds the list of ArgCfg(s) to help text, and ArgsUsage makes the part of a usage line
+for them, like "<src>... <dest>".
+Help#AddOptGroups divides OptCfg(s) into sections by their Group fields, and shows each section
+with its Group name as the title, aligning the descriptions of all sections to a shared column.
+
+Help#AddUsage synthesizes a usage line from the name, OptCfgs, and ArgCfgs of a Cmd instance,
+like "Usage: app [OPTIONS] --file <path> <src>... <dest>", where required options are shown with
+their ArgInHelp and the other options are summarized into "[OPTIONS]".
+If the usage line is longer than the line width, it is wrapped and the following lines are
+indented to the position after the command name.
+
+Help#Fprint writes help texts to any io.Writer, like os.Stderr or a buffer, breaking lines with the
+specified line width.
+If the line width is zero, the value of the environment variable COLUMNS or the column count of
+the current terminal is used.
+
+Help#SetStyle sets the styles of option names, placeholders of option arguments, and section
+titles, like DefaultHelpStyle.
+The styles are applied by Help#Print and Help#Fprint after lines are broken, so that they do not
+affect the alignment, and are disabled when the output is not a terminal or the environment
+variable NO_COLOR is set.
+
+The help texts added to Help can be rendered not only as terminal lines but also in Markdown with
+Help#Markdown, as a man page in roff with Help#Roff, and as an HTML fragment with Help#HTML, so
+that documents can be generated from the same source as the in-binary help.
+
 # Parse for a OptStore struct
 
 The Cmd struct has the method ParseFor which parses command line arguments and set their option
@@ -140,7 +245,28 @@ argument, therefore it can appear once in command line arguments.
 If the type is an array, the option can takes multiple option arguments, therefore it can appear
 multiple times in command line arguments.
 
-The struct tags used in a option store struct are optcfg, optdesc, and optarg.
+In addition, time.Duration, time.Time, net.IP, net.IPNet, netip.Addr, netip.Prefix, *url.URL,
+*regexp.Regexp, os.FileMode, and the arrays of them are supported as the types of fields.
+A time.Time option argument is parsed with the layout specified with the struct tag optlayout,
+like `optlayout:"2006-01-02"`, or time.RFC3339 if not specified, and an os.FileMode option argument
+is parsed as an octal number.
+
+Moreover, a type of which pointer implements Value or encoding.TextUnmarshaler can be used as the
+type of a field, and option arguments are converted with its Set or UnmarshalText method.
+If such a field implements Value and has a value before parsing, the value's String is shown as
+the default value of the option in help text, like "(default: info)", unless it is same as the
+String of the zero value, like the flag package of the standard library does.
+This value is not used as an option argument, so the option is not regarded as given.
+
+A field of a map type of which key type is string, like map[string]string or map[string]int, can
+be used for an option which takes option arguments of the form of key=value multiple times.
+
+A field of a pointer type to the above types, like *int or *time.Duration, is left nil if the
+option is given neither in command line arguments, environment variables, configuration files, nor
+default values, so that it can be distinguished from the zero value.
+
+The struct tags used in a option store struct are optcfg, optdesc, optarg, optenv, optconfig,
+optreq, optexcl, optrequires, optaction, optgroup, optnodupkey, optprefix, optpos, and optlayout.
 optcfg is what to specify option configurations other than Desc and AtgInHelp.
 The format of optcfg is as follows:
 
@@ -152,6 +278,41 @@ The format of optcfg is as follows:
 
 optdesc is what to specify a option description.
 And optarg is what to specify a text for an option argument value in help text.
+optenv is what to specify environment variable names separated by commas, like
+`optenv:"FOO_BAR,FOO"`.
+optconfig is what to specify that the option argument is a path of a configuration file, like
+`optconfig:"true"`.
+optreq is what to specify that the option is required, like `optreq:"true"`.
+optexcl is what to specify the name of a group of mutually exclusive options, like
+`optexcl:"format"`, and optrequires is what to specify the names of the options required by the
+option, separated by commas, like `optrequires:"password,host"`.
+optaction is what to specify that the option requests help text or a version, like
+`optaction:"help"` or `optaction:"version"`.
+optgroup is what to specify the name of the section of help text in which the option is shown,
+like `optgroup:"Network options"`.
+optnodupkey is what to specify that a map option rejects a key given multiple times, like
+`optnodupkey:"true"`.
+optprefix is what to specify the prefix of the option names of the fields in an embedded or nested
+struct field, like `optprefix:"db-"`.
+A nested struct field without optprefix uses its field name and a hyphen as the prefix, like
+--DB-host, and `optprefix:""` means no prefix.
+The prefix is also prepended to the names in optrequires which are the option names in the same
+struct, and to the group name in optexcl of the fields in such a struct field.
+The names in optrequires which are the field names in the same struct are converted to the store
+keys, and the other names are left as they are to refer to the options outside the struct.
+
+The fields of an embedded struct are handled as the options of the option store, so that a set of
+options, like logging options, can be shared among multiple option stores.
+The fields of a nested struct field are handled as options too, and their store keys are joined
+with the name of the nested struct field and a dot, like DB.Host, which also matches the keys of
+nested objects in configuration files.
+
+A field with the struct tag optpos is not an option but receives command arguments.
+`optpos:"0"`, `optpos:"1"`, ... specify the positions of required command arguments, and
+`optpos:"rest"`, which is allowed only for a slice field, receives the rest of command arguments.
+The ArgCfg(s) made from these fields are set to Cmd#ArgCfgs, and optarg and optdesc of them are
+used as the Name and the Desc.
+The fields of embedded structs can have optpos too, but the fields of nested struct fields cannot.
 
 NOTE: A default value of empty string array option in the struct tag is `[]`,
 like: `optcfg:"name=[]"`,
@@ -287,5 +448,94 @@ The folowing is an example code using Cmd#parse_until_sub_cmd:
 	subCmd.OptArgs("x")        // []
 	subCmd.OptArgs("y")        // []
 	subCmd.OptArgs("z")        // [2 3]
+
+# Dispatch along a command tree
+
+Instead of chaining Cmd#ParseUntilSubCmdWith calls by hand, a command tree can be declared with
+CmdCfg, and Cmd#Dispatch parses command line arguments along the tree and calls the Run function of
+the matched command configuration.
+If a sub command which is not configured is given, Cmd#Dispatch returns UnconfiguredSubCmd error.
+
+	// os.Args = []string{"path/to/app", "-v", "remote", "add", "--track", "main", "origin"}
+
+	cmdCfg := cliargs.CmdCfg{
+	    OptCfgs: []cliargs.OptCfg{
+	        cliargs.OptCfg{Names: []string{"verbose", "v"}},
+	    },
+	    SubCmds: []cliargs.CmdCfg{
+	        cliargs.CmdCfg{
+	            Name: "remote",
+	            Aliases: []string{"r"},
+	            SubCmds: []cliargs.CmdCfg{
+	                cliargs.CmdCfg{
+	                    Name: "add",
+	                    OptCfgs: []cliargs.OptCfg{
+	                        cliargs.OptCfg{Names: []string{"track", "t"}, HasArg: true},
+	                    },
+	                    Run: func(cmd cliargs.Cmd, parents []cliargs.Cmd) error {
+	                        parents[0].HasOpt("verbose")  // true
+	                        parents[1].Name               // remote
+	                        cmd.Name                      // add
+	                        cmd.Args                      // [origin]
+	                        cmd.OptArg("track")           // main
+	                        return nil
+	                    },
+	                },
+	            },
+	        },
+	    },
+	}
+
+	cmd := cliargs.NewCmd()
+	err := cmd.Dispatch(cmdCfg)
+
+# Shell completion
+
+A completion script for bash, zsh, or fish can be created from a command tree declared with CmdCfg
+by MakeBashCompletion, MakeZshCompletion, or MakeFishCompletion.
+These scripts complete option names and sub command names (including aliases) at each level of the
+tree, and skip the word following an option which takes an argument.
+
+	cmdCfg := cliargs.CmdCfg{
+	    Name: "app",
+	    OptCfgs: []cliargs.OptCfg{
+	        cliargs.OptCfg{Names: []string{"verbose", "v"}, Desc: "Prints logs."},
+	    },
+	    SubCmds: []cliargs.CmdCfg{
+	        cliargs.CmdCfg{Name: "remote", Aliases: []string{"r"}, Desc: "Manages remotes."},
+	    },
+	}
+
+	script, err := cliargs.MakeBashCompletion(cmdCfg)
+	fmt.Print(script)  // $ source <(app completion bash)
+
+The arguments of an option can be completed at runtime by setting a function to the Completer field
+of its OptCfg.
+For such an option, the completion scripts run the program with the reserved command argument
+__complete followed by the words in the command line, and Cmd#Dispatch (or Cmd#Complete) writes the
+candidates returned by the function to stdout instead of running the command.
+The parsing methods, like Cmd#ParseWith, don't handle __complete, so a program which doesn't use
+Cmd#Dispatch needs to call Cmd#Complete before parsing, as follows:
+
+	cmd := cliargs.NewCmd()
+	if ok, err := cmd.Complete(cmdCfg, os.Stdout); ok {
+	    return err
+	}
+	err := cmd.ParseWith(cmdCfg.OptCfgs)
+
+	profiles := func(prefix string) []string {
+	    return listProfilesOnDisk()
+	}
+
+	cmdCfg := cliargs.CmdCfg{
+	    Name: "app",
+	    OptCfgs: []cliargs.OptCfg{
+	        cliargs.OptCfg{Names: []string{"profile"}, HasArg: true, Completer: &profiles},
+	    },
+	}
+
+	// os.Args = []string{"path/to/app", "__complete", "--profile", "d"}
+	cmd := cliargs.NewCmd()
+	err := cmd.Dispatch(cmdCfg)  // prints the profiles starting with "d", one per line
 */
 package cliargs
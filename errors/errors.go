@@ -7,8 +7,10 @@
 package errors
 
 import (
+	goerrors "errors"
 	"fmt"
 	"reflect"
+	"strings"
 )
 
 // InvalidOption is the error interface which provides method declarations
@@ -36,12 +38,28 @@ func (e OptionContainsInvalidChar) GetOption() string {
 
 // UnconfiguredOption is the error which indicates that there is no
 // configuration about the input option.
+//
+// This error can have the configured option names which are similar to the
+// input option as suggestions, which are retrieved with the Suggestions method.
+// Such an error is created with NewUnconfiguredOption, and is not equal to an
+// error without suggestions with ==, so use errors.Is of the standard package
+// to check the option, which ignores suggestions.
 type UnconfiguredOption struct {
-	Option string
+	Option      string
+	suggestions string
+}
+
+// NewUnconfiguredOption is the function that creates an UnconfiguredOption
+// error with the option names similar to the input option.
+func NewUnconfiguredOption(option string, suggestions []string) UnconfiguredOption {
+	return UnconfiguredOption{Option: option, suggestions: joinSuggestions(suggestions)}
 }
 
 // Error is the method to retrieve the message of this error.
 func (e UnconfiguredOption) Error() string {
+	if len(e.suggestions) > 0 {
+		return fmt.Sprintf("UnconfiguredOption{Option:%s,Suggestions:%v}", e.Option, e.Suggestions())
+	}
 	return fmt.Sprintf("UnconfiguredOption{Option:%s}", e.Option)
 }
 
@@ -50,6 +68,73 @@ func (e UnconfiguredOption) GetOption() string {
 	return e.Option
 }
 
+// Suggestions is the method to retrieve the configured option names which are
+// similar to the input option.
+func (e UnconfiguredOption) Suggestions() []string {
+	return splitSuggestions(e.suggestions)
+}
+
+// Is is the method to check whether the target error is an UnconfiguredOption error of the same
+// option, regardless of suggestions.
+func (e UnconfiguredOption) Is(target error) bool {
+	t, ok := target.(UnconfiguredOption)
+	return ok && t.Option == e.Option
+}
+
+// UnconfiguredSubCmd is the error which indicates that there is no configuration about the input
+// sub command.
+//
+// This error can have the configured sub command names which are similar to the input sub command
+// as suggestions, which are retrieved with the Suggestions method.
+// Such an error is created with NewUnconfiguredSubCmd, and errors.Is of the standard package
+// ignores suggestions.
+type UnconfiguredSubCmd struct {
+	Name        string
+	suggestions string
+}
+
+// NewUnconfiguredSubCmd is the function that creates an UnconfiguredSubCmd error with the sub
+// command names similar to the input sub command.
+func NewUnconfiguredSubCmd(name string, suggestions []string) UnconfiguredSubCmd {
+	return UnconfiguredSubCmd{Name: name, suggestions: joinSuggestions(suggestions)}
+}
+
+// Error is the method to retrieve the message of this error.
+func (e UnconfiguredSubCmd) Error() string {
+	if len(e.suggestions) > 0 {
+		return fmt.Sprintf("UnconfiguredSubCmd{Name:%s,Suggestions:%v}", e.Name, e.Suggestions())
+	}
+	return fmt.Sprintf("UnconfiguredSubCmd{Name:%s}", e.Name)
+}
+
+// Suggestions is the method to retrieve the configured sub command names which are similar to the
+// input sub command.
+func (e UnconfiguredSubCmd) Suggestions() []string {
+	return splitSuggestions(e.suggestions)
+}
+
+// Is is the method to check whether the target error is an UnconfiguredSubCmd error of the same
+// sub command, regardless of suggestions.
+func (e UnconfiguredSubCmd) Is(target error) bool {
+	t, ok := target.(UnconfiguredSubCmd)
+	return ok && t.Name == e.Name
+}
+
+// Suggestions are held as a string joined with NUL characters, which are never included in option
+// names nor command line arguments, to keep the errors comparable.
+const suggestionSep = "\x00"
+
+func joinSuggestions(suggestions []string) string {
+	return strings.Join(suggestions, suggestionSep)
+}
+
+func splitSuggestions(s string) []string {
+	if len(s) == 0 {
+		return nil
+	}
+	return strings.Split(s, suggestionSep)
+}
+
 // OptionNeedsArg is the error which indicates that an option is input with
 // no option argument though its option configuration requires option
 // argument (.HasArg = true).
@@ -160,6 +245,25 @@ func (e ConfigHasDefaultsButHasNoArg) GetOption() string {
 	return e.Name
 }
 
+// ConfigIsMapButHasNoArg is the error which indicates that an option
+// configuration contradicts that the option must be a map (.IsMap = true) but
+// must have no option argument (.HasArg = false).
+type ConfigIsMapButHasNoArg struct {
+	StoreKey string
+	Name     string
+}
+
+// Error is the method to retrieve the message of this error.
+func (e ConfigIsMapButHasNoArg) Error() string {
+	return fmt.Sprintf("ConfigIsMapButHasNoArg{StoreKey:%s,Name:%s}", e.StoreKey, e.Name)
+}
+
+// GetOption is the method to retrieve the first name of the option in the option
+// configuration that caused this error.
+func (e ConfigIsMapButHasNoArg) GetOption() string {
+	return e.Name
+}
+
 // OptionNameIsDuplicated is the error which indicates that an option argument
 // is invalidated by the validator in the option configuration.
 type OptionNameIsDuplicated struct {
@@ -215,7 +319,8 @@ func (e OptionStoreIsNotChangeable) Error() string {
 }
 
 // BadFieldType is the error which indicates that a type of a field of the option store is neither
-// a boolean, a number, a string, nor an array of numbers or strings.
+// a boolean, a number, a string, one of the supported standard types like time.Duration, nor an
+// array of them.
 type BadFieldType struct {
 	Option string
 	Field  string
@@ -226,3 +331,328 @@ type BadFieldType struct {
 func (e BadFieldType) Error() string {
 	return fmt.Sprintf("BadFieldType{Option:%s,Field:%s,Type:%v}", e.Option, e.Field, e.Type)
 }
+
+// ConfigFileIsNotReadable is the error which indicates that a configuration file, which is
+// specified as an option argument of the option of which the configuration has IsConfigFile = true,
+// cannot be read.
+type ConfigFileIsNotReadable struct {
+	File  string
+	Cause error
+}
+
+// Error is the method to retrieve the message of this error.
+func (e ConfigFileIsNotReadable) Error() string {
+	return fmt.Sprintf("ConfigFileIsNotReadable{File:%s,Cause:%v}", e.File, e.Cause)
+}
+
+// Unwrap is the method to get an error which is wrapped in this error.
+func (e ConfigFileIsNotReadable) Unwrap() error {
+	return e.Cause
+}
+
+// ConfigFileIsInvalid is the error which indicates that the content of a configuration file is
+// invalid.
+// Line is the line number where the error is found, or zero if unknown.
+type ConfigFileIsInvalid struct {
+	File  string
+	Line  int
+	Cause error
+}
+
+// Error is the method to retrieve the message of this error.
+func (e ConfigFileIsInvalid) Error() string {
+	return fmt.Sprintf("ConfigFileIsInvalid{File:%s,Line:%d,Cause:%v}", e.File, e.Line, e.Cause)
+}
+
+// Unwrap is the method to get an error which is wrapped in this error.
+func (e ConfigFileIsInvalid) Unwrap() error {
+	return e.Cause
+}
+
+// EnvVarIsInvalid is the error which indicates that the value of the environment variable EnvVar,
+// which is used as the option argument(s) of the option, is invalid.
+// Cause holds the error which occurred in checking the value, like OptionArgIsInvalid.
+type EnvVarIsInvalid struct {
+	EnvVar   string
+	Option   string
+	StoreKey string
+	Value    string
+	Cause    error
+}
+
+// Error is the method to retrieve the message of this error.
+func (e EnvVarIsInvalid) Error() string {
+	return fmt.Sprintf("EnvVarIsInvalid{EnvVar:%s,Option:%s,StoreKey:%s,Value:%s,Cause:%v}",
+		e.EnvVar, e.Option, e.StoreKey, e.Value, e.Cause)
+}
+
+// Unwrap is the method to get an error which is wrapped in this error.
+func (e EnvVarIsInvalid) Unwrap() error {
+	return e.Cause
+}
+
+// GetOption is the method to retrieve the first name of the option in the option
+// configuration that caused this error.
+func (e EnvVarIsInvalid) GetOption() string {
+	return e.Option
+}
+
+// ConfigValueIsInvalid is the error which indicates that the value of the key Key in configuration
+// files, which is used as the option argument(s) of the option, is invalid.
+// If the value is an array, Value holds its elements joined with commas.
+// Cause holds the error which occurred in checking the value, like OptionArgIsInvalid.
+type ConfigValueIsInvalid struct {
+	Key      string
+	Option   string
+	StoreKey string
+	Value    string
+	Cause    error
+}
+
+// Error is the method to retrieve the message of this error.
+func (e ConfigValueIsInvalid) Error() string {
+	return fmt.Sprintf("ConfigValueIsInvalid{Key:%s,Option:%s,StoreKey:%s,Value:%s,Cause:%v}",
+		e.Key, e.Option, e.StoreKey, e.Value, e.Cause)
+}
+
+// Unwrap is the method to get an error which is wrapped in this error.
+func (e ConfigValueIsInvalid) Unwrap() error {
+	return e.Cause
+}
+
+// GetOption is the method to retrieve the first name of the option in the option
+// configuration that caused this error.
+func (e ConfigValueIsInvalid) GetOption() string {
+	return e.Option
+}
+
+// MissingRequiredOption is the error which indicates that options of which option configurations
+// are required (.Required = true) are given neither in command line arguments, environment
+// variables, configuration files, nor default values.
+// Options holds the first names of all such options.
+type MissingRequiredOption struct {
+	Options []string
+}
+
+// Error is the method to retrieve the message of this error.
+func (e MissingRequiredOption) Error() string {
+	return fmt.Sprintf("MissingRequiredOption{Options:%v}", e.Options)
+}
+
+// GetOption is the method to retrieve the first name of the first missing option.
+func (e MissingRequiredOption) GetOption() string {
+	if len(e.Options) == 0 {
+		return ""
+	}
+	return e.Options[0]
+}
+
+// OptionsAreExclusive is the error which indicates that multiple options which belong to the same
+// exclusive group (.ExclusiveGroup) are given.
+// Options holds the first names of the given options in the group.
+type OptionsAreExclusive struct {
+	Group   string
+	Options []string
+}
+
+// Error is the method to retrieve the message of this error.
+func (e OptionsAreExclusive) Error() string {
+	return fmt.Sprintf("OptionsAreExclusive{Group:%s,Options:%v}", e.Group, e.Options)
+}
+
+// GetOption is the method to retrieve the first name of the first given option in the group.
+func (e OptionsAreExclusive) GetOption() string {
+	if len(e.Options) == 0 {
+		return ""
+	}
+	return e.Options[0]
+}
+
+// OptionGroupIsMissing is the error which indicates that none of the options which belong to a
+// required exclusive group is given.
+// Options holds the first names of all options in the group.
+type OptionGroupIsMissing struct {
+	Group   string
+	Options []string
+}
+
+// Error is the method to retrieve the message of this error.
+func (e OptionGroupIsMissing) Error() string {
+	return fmt.Sprintf("OptionGroupIsMissing{Group:%s,Options:%v}", e.Group, e.Options)
+}
+
+// OptionRequiresOthers is the error which indicates that an option is given but other options
+// which the option requires (.Requires) are not present.
+// Missing holds the names of the required options which are not present.
+type OptionRequiresOthers struct {
+	Option   string
+	StoreKey string
+	Missing  []string
+}
+
+// Error is the method to retrieve the message of this error.
+func (e OptionRequiresOthers) Error() string {
+	return fmt.Sprintf("OptionRequiresOthers{Option:%s,StoreKey:%s,Missing:%v}",
+		e.Option, e.StoreKey, e.Missing)
+}
+
+// GetOption is the method to retrieve the first name of the option which requires others.
+func (e OptionRequiresOthers) GetOption() string {
+	return e.Option
+}
+
+// HelpIsRequested is the error which indicates that the option of which option configuration has
+// IsHelp = true is given in command line arguments.
+// This error is returned instead of any other errors, so that the caller can print a help text and
+// exit normally.
+type HelpIsRequested struct {
+	Option string
+}
+
+// Error is the method to retrieve the message of this error.
+func (e HelpIsRequested) Error() string {
+	return fmt.Sprintf("HelpIsRequested{Option:%s}", e.Option)
+}
+
+// VersionIsRequested is the error which indicates that the option of which option configuration
+// has IsVersion = true is given in command line arguments.
+// This error is returned instead of any other errors, so that the caller can print a version and
+// exit normally.
+type VersionIsRequested struct {
+	Option string
+}
+
+// Error is the method to retrieve the message of this error.
+func (e VersionIsRequested) Error() string {
+	return fmt.Sprintf("VersionIsRequested{Option:%s}", e.Option)
+}
+
+// MissingCmdArg is the error which indicates that fewer command arguments are given than the
+// minimum number of the argument configuration (ArgCfg) of which the name is Name.
+type MissingCmdArg struct {
+	Name string
+}
+
+// Error is the method to retrieve the message of this error.
+func (e MissingCmdArg) Error() string {
+	return fmt.Sprintf("MissingCmdArg{Name:%s}", e.Name)
+}
+
+// TooManyCmdArgs is the error which indicates that more command arguments are given than the
+// argument configurations (ArgCfg) can take.
+// Args holds the command arguments which are left over.
+type TooManyCmdArgs struct {
+	Args []string
+}
+
+// Error is the method to retrieve the message of this error.
+func (e TooManyCmdArgs) Error() string {
+	return fmt.Sprintf("TooManyCmdArgs{Args:%v}", e.Args)
+}
+
+// CmdArgIsInvalid is the error which indicates that a command argument is invalidated by the
+// validator in the argument configuration (ArgCfg) of which the name is Name, or cannot be
+// converted to the type of the field of the option store.
+type CmdArgIsInvalid struct {
+	Name  string
+	Arg   string
+	Cause error
+}
+
+// Error is the method to retrieve the message of this error.
+func (e CmdArgIsInvalid) Error() string {
+	return fmt.Sprintf("CmdArgIsInvalid{Name:%s,Arg:%s,Cause:%v}", e.Name, e.Arg, e.Cause)
+}
+
+// Unwrap is the method to get an error which is wrapped in this error.
+func (e CmdArgIsInvalid) Unwrap() error {
+	return e.Cause
+}
+
+// ArgPosIsInvalid is the error which indicates that the value of the struct tag optpos of a field
+// of the option store is invalid.
+// The value must be "rest" for a slice field, or a position number for a non-slice field, and the
+// position numbers must be sequential from 0.
+// This error also indicates that a field in a nested struct field of the option store has the
+// struct tag optpos, and then Field is the field names joined with dots.
+type ArgPosIsInvalid struct {
+	Field string
+	Pos   string
+}
+
+// Error is the method to retrieve the message of this error.
+func (e ArgPosIsInvalid) Error() string {
+	return fmt.Sprintf("ArgPosIsInvalid{Field:%s,Pos:%s}", e.Field, e.Pos)
+}
+
+// OptArgIsNotKeyValue is the error which indicates that an option argument of a map option
+// (.IsMap = true) does not have the form of key=value.
+// This error is set to the Cause field of OptionArgIsInvalid error.
+type OptArgIsNotKeyValue struct {
+	OptArg string
+}
+
+// Error is the method to retrieve the message of this error.
+func (e OptArgIsNotKeyValue) Error() string {
+	return fmt.Sprintf("OptArgIsNotKeyValue{OptArg:%s}", e.OptArg)
+}
+
+// MapKeyIsDuplicated is the error which indicates that a key is given multiple times to a map
+// option of which option configuration rejects duplicated keys (.RejectsDupKey = true).
+type MapKeyIsDuplicated struct {
+	StoreKey string
+	Option   string
+	Key      string
+}
+
+// Error is the method to retrieve the message of this error.
+func (e MapKeyIsDuplicated) Error() string {
+	return fmt.Sprintf("MapKeyIsDuplicated{StoreKey:%s,Option:%s,Key:%s}",
+		e.StoreKey, e.Option, e.Key)
+}
+
+// GetOption is the method to retrieve the name of the option that caused this error.
+func (e MapKeyIsDuplicated) GetOption() string {
+	return e.Option
+}
+
+// ErrorList is the error which holds all errors that occurred during parsing command line
+// arguments.
+// This error is returned by the parsing methods of a Cmd instance of which the flag to collect all
+// errors is set.
+//
+// errors.Is and errors.As of the standard package apply to each error in this list.
+type ErrorList struct {
+	Errs []error
+}
+
+// Error is the method to retrieve the message of this error.
+func (e ErrorList) Error() string {
+	return fmt.Sprintf("ErrorList{Errs:%v}", e.Errs)
+}
+
+// Unwrap is the method to get the errors which are held in this error.
+func (e ErrorList) Unwrap() []error {
+	return e.Errs
+}
+
+// Is is the method to check whether any error in this list matches the target error.
+func (e ErrorList) Is(target error) bool {
+	for _, err := range e.Errs {
+		if goerrors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// As is the method to find the first error in this list that matches the target, and if one is
+// found, sets the target to that error value.
+func (e ErrorList) As(target any) bool {
+	for _, err := range e.Errs {
+		if goerrors.As(err, target) {
+			return true
+		}
+	}
+	return false
+}
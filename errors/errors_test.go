@@ -1,6 +1,7 @@
 package errors_test
 
 import (
+	goerrors "errors"
 	"fmt"
 	"reflect"
 	"testing"
@@ -29,6 +30,38 @@ func TestErrors_UnconfiguredOption(t *testing.T) {
 	assert.Equal(t, ee.GetOption(), "foo")
 }
 
+func TestErrors_UnconfiguredOption_withSuggestions(t *testing.T) {
+	e := errors.NewUnconfiguredOption("verbsoe", []string{"verbose", "version"})
+	assert.Equal(t, e.Error(), "UnconfiguredOption{Option:verbsoe,Suggestions:[verbose version]}")
+	assert.True(t, goerrors.Is(e, errors.UnconfiguredOption{Option: "verbsoe"}))
+	assert.False(t, goerrors.Is(e, errors.UnconfiguredOption{Option: "verbose"}))
+	assert.Equal(t, e.Option, "verbsoe")
+	assert.Equal(t, e.Suggestions(), []string{"verbose", "version"})
+
+	var err error = e
+	assert.True(t, err == error(errors.NewUnconfiguredOption("verbsoe", []string{"verbose", "version"})))
+	assert.False(t, err == error(errors.UnconfiguredOption{Option: "verbsoe"}))
+	assert.Nil(t, errors.UnconfiguredOption{Option: "verbsoe"}.Suggestions())
+}
+
+func TestErrors_UnconfiguredSubCmd_withSuggestions(t *testing.T) {
+	e := errors.NewUnconfiguredSubCmd("stat", []string{"status"})
+	assert.Equal(t, e.Error(), "UnconfiguredSubCmd{Name:stat,Suggestions:[status]}")
+	assert.True(t, goerrors.Is(e, errors.UnconfiguredSubCmd{Name: "stat"}))
+	assert.False(t, goerrors.Is(e, errors.UnconfiguredSubCmd{Name: "status"}))
+	assert.Equal(t, e.Name, "stat")
+	assert.Equal(t, e.Suggestions(), []string{"status"})
+
+	var err error = e
+	assert.True(t, err == error(errors.NewUnconfiguredSubCmd("stat", []string{"status"})))
+}
+
+func TestErrors_UnconfiguredSubCmd(t *testing.T) {
+	e := errors.UnconfiguredSubCmd{Name: "foo"}
+	assert.Equal(t, e.Name, "foo")
+	assert.Equal(t, e.Error(), "UnconfiguredSubCmd{Name:foo}")
+}
+
 func TestErrors_OptionNeedsArg(t *testing.T) {
 	e := errors.OptionNeedsArg{Option: "foo", StoreKey: "Foo"}
 	assert.Equal(t, e.Option, "foo")
@@ -135,3 +168,199 @@ func TestErrors_BadFieldType(t *testing.T) {
 	e := errors.BadFieldType{Option: "foo", Field: "Foo", Type: reflect.TypeOf(0)}
 	assert.Equal(t, e.Error(), "BadFieldType{Option:foo,Field:Foo,Type:int}")
 }
+
+func TestErrors_MissingRequiredOption(t *testing.T) {
+	e := errors.MissingRequiredOption{Options: []string{"foo", "b"}}
+	assert.Equal(t, e.Options, []string{"foo", "b"})
+	assert.Equal(t, e.GetOption(), "foo")
+	assert.Equal(t, e.Error(), "MissingRequiredOption{Options:[foo b]}")
+
+	var ee errors.InvalidOption = e
+	assert.Equal(t, ee.GetOption(), "foo")
+
+	assert.Equal(t, errors.MissingRequiredOption{}.GetOption(), "")
+}
+
+func TestErrors_OptionsAreExclusive(t *testing.T) {
+	e := errors.OptionsAreExclusive{Group: "format", Options: []string{"json", "yaml"}}
+	assert.Equal(t, e.Group, "format")
+	assert.Equal(t, e.Options, []string{"json", "yaml"})
+	assert.Equal(t, e.GetOption(), "json")
+	assert.Equal(t, e.Error(), "OptionsAreExclusive{Group:format,Options:[json yaml]}")
+
+	var ee errors.InvalidOption = e
+	assert.Equal(t, ee.GetOption(), "json")
+
+	assert.Equal(t, errors.OptionsAreExclusive{}.GetOption(), "")
+}
+
+func TestErrors_OptionGroupIsMissing(t *testing.T) {
+	e := errors.OptionGroupIsMissing{Group: "in", Options: []string{"file", "stdin"}}
+	assert.Equal(t, e.Group, "in")
+	assert.Equal(t, e.Options, []string{"file", "stdin"})
+	assert.Equal(t, e.Error(), "OptionGroupIsMissing{Group:in,Options:[file stdin]}")
+}
+
+func TestErrors_OptionRequiresOthers(t *testing.T) {
+	e := errors.OptionRequiresOthers{
+		Option: "user", StoreKey: "User", Missing: []string{"password", "host"}}
+	assert.Equal(t, e.Option, "user")
+	assert.Equal(t, e.StoreKey, "User")
+	assert.Equal(t, e.Missing, []string{"password", "host"})
+	assert.Equal(t, e.GetOption(), "user")
+	assert.Equal(t, e.Error(),
+		"OptionRequiresOthers{Option:user,StoreKey:User,Missing:[password host]}")
+
+	var ee errors.InvalidOption = e
+	assert.Equal(t, ee.GetOption(), "user")
+}
+
+func TestErrors_HelpIsRequested(t *testing.T) {
+	e := errors.HelpIsRequested{Option: "help"}
+	assert.Equal(t, e.Option, "help")
+	assert.Equal(t, e.Error(), "HelpIsRequested{Option:help}")
+}
+
+func TestErrors_VersionIsRequested(t *testing.T) {
+	e := errors.VersionIsRequested{Option: "V"}
+	assert.Equal(t, e.Option, "V")
+	assert.Equal(t, e.Error(), "VersionIsRequested{Option:V}")
+}
+
+func TestErrors_MissingCmdArg(t *testing.T) {
+	e := errors.MissingCmdArg{Name: "file"}
+	assert.Equal(t, e.Name, "file")
+	assert.Equal(t, e.Error(), "MissingCmdArg{Name:file}")
+}
+
+func TestErrors_TooManyCmdArgs(t *testing.T) {
+	e := errors.TooManyCmdArgs{Args: []string{"a", "b"}}
+	assert.Equal(t, e.Args, []string{"a", "b"})
+	assert.Equal(t, e.Error(), "TooManyCmdArgs{Args:[a b]}")
+}
+
+func TestErrors_CmdArgIsInvalid(t *testing.T) {
+	e0 := fmt.Errorf("type error")
+	e := errors.CmdArgIsInvalid{Name: "num", Arg: "x", Cause: e0}
+	assert.Equal(t, e.Name, "num")
+	assert.Equal(t, e.Arg, "x")
+	assert.Equal(t, e.Cause, e0)
+	assert.Equal(t, e.Error(), "CmdArgIsInvalid{Name:num,Arg:x,Cause:type error}")
+	assert.Equal(t, e.Unwrap(), e0)
+	assert.True(t, goerrors.Is(e, e0))
+}
+
+func TestErrors_ArgPosIsInvalid(t *testing.T) {
+	e := errors.ArgPosIsInvalid{Field: "Src", Pos: "first"}
+	assert.Equal(t, e.Field, "Src")
+	assert.Equal(t, e.Pos, "first")
+	assert.Equal(t, e.Error(), "ArgPosIsInvalid{Field:Src,Pos:first}")
+}
+
+func TestErrors_ConfigIsMapButHasNoArg(t *testing.T) {
+	e := errors.ConfigIsMapButHasNoArg{StoreKey: "Foo", Name: "foo"}
+	assert.Equal(t, e.StoreKey, "Foo")
+	assert.Equal(t, e.Name, "foo")
+	assert.Equal(t, e.Error(), "ConfigIsMapButHasNoArg{StoreKey:Foo,Name:foo}")
+	assert.Equal(t, e.GetOption(), "foo")
+
+	var ee errors.InvalidOption = e
+	assert.Equal(t, ee.GetOption(), "foo")
+}
+
+func TestErrors_OptArgIsNotKeyValue(t *testing.T) {
+	e := errors.OptArgIsNotKeyValue{OptArg: "env"}
+	assert.Equal(t, e.OptArg, "env")
+	assert.Equal(t, e.Error(), "OptArgIsNotKeyValue{OptArg:env}")
+}
+
+func TestErrors_MapKeyIsDuplicated(t *testing.T) {
+	e := errors.MapKeyIsDuplicated{StoreKey: "Label", Option: "label", Key: "env"}
+	assert.Equal(t, e.StoreKey, "Label")
+	assert.Equal(t, e.Option, "label")
+	assert.Equal(t, e.Key, "env")
+	assert.Equal(t, e.Error(), "MapKeyIsDuplicated{StoreKey:Label,Option:label,Key:env}")
+	assert.Equal(t, e.GetOption(), "label")
+
+	var ee errors.InvalidOption = e
+	assert.Equal(t, ee.GetOption(), "label")
+}
+
+func TestErrors_EnvVarIsInvalid(t *testing.T) {
+	e0 := errors.OptionArgIsInvalid{
+		StoreKey: "Port", Option: "port", OptArg: "x", TypeKind: reflect.Int}
+	e := errors.EnvVarIsInvalid{
+		EnvVar: "PORT", Option: "port", StoreKey: "Port", Value: "x", Cause: e0}
+	assert.Equal(t, e.EnvVar, "PORT")
+	assert.Equal(t, e.Option, "port")
+	assert.Equal(t, e.StoreKey, "Port")
+	assert.Equal(t, e.Value, "x")
+	assert.Equal(t, e.Error(), "EnvVarIsInvalid{EnvVar:PORT,Option:port,StoreKey:Port,Value:x,"+
+		"Cause:OptionArgIsInvalid{StoreKey:Port,Option:port,OptArg:x,TypeKind:int,Cause:<nil>}}")
+	assert.Equal(t, e.Unwrap(), e0)
+	assert.Equal(t, e.GetOption(), "port")
+	assert.True(t, goerrors.Is(e, e0))
+}
+
+func TestErrors_ConfigValueIsInvalid(t *testing.T) {
+	e0 := errors.OptionIsNotArray{Option: "port", StoreKey: "Port"}
+	e := errors.ConfigValueIsInvalid{
+		Key: "Port", Option: "port", StoreKey: "Port", Value: "1,2", Cause: e0}
+	assert.Equal(t, e.Key, "Port")
+	assert.Equal(t, e.Option, "port")
+	assert.Equal(t, e.StoreKey, "Port")
+	assert.Equal(t, e.Value, "1,2")
+	assert.Equal(t, e.Error(), "ConfigValueIsInvalid{Key:Port,Option:port,StoreKey:Port,"+
+		"Value:1,2,Cause:OptionIsNotArray{Option:port,StoreKey:Port}}")
+	assert.Equal(t, e.Unwrap(), e0)
+	assert.Equal(t, e.GetOption(), "port")
+	assert.True(t, goerrors.Is(e, e0))
+}
+
+func TestErrors_ErrorList(t *testing.T) {
+	e0 := fmt.Errorf("type error")
+	e := errors.ErrorList{Errs: []error{
+		errors.UnconfiguredOption{Option: "foo"},
+		errors.OptionArgIsInvalid{
+			StoreKey: "Bar", Option: "bar", OptArg: "xx", TypeKind: reflect.Int, Cause: e0},
+	}}
+
+	assert.Equal(t, len(e.Errs), 2)
+	assert.Equal(t, e.Unwrap(), e.Errs)
+	assert.Equal(t, e.Error(), "ErrorList{Errs:[UnconfiguredOption{Option:foo} "+
+		"OptionArgIsInvalid{StoreKey:Bar,Option:bar,OptArg:xx,TypeKind:int,Cause:type error}]}")
+
+	assert.True(t, goerrors.Is(e, errors.UnconfiguredOption{Option: "foo"}))
+	assert.True(t, goerrors.Is(e, e0))
+	assert.False(t, goerrors.Is(e, errors.UnconfiguredOption{Option: "bar"}))
+
+	var e1 errors.OptionArgIsInvalid
+	assert.True(t, goerrors.As(e, &e1))
+	assert.Equal(t, e1.Option, "bar")
+
+	var e2 errors.OptionNeedsArg
+	assert.False(t, goerrors.As(e, &e2))
+
+	var e3 errors.InvalidOption
+	assert.True(t, goerrors.As(e, &e3))
+	assert.Equal(t, e3.GetOption(), "foo")
+}
+
+func TestErrors_ConfigFileIsNotReadable(t *testing.T) {
+	e0 := fmt.Errorf("no such file")
+	e := errors.ConfigFileIsNotReadable{File: "app.json", Cause: e0}
+	assert.Equal(t, e.File, "app.json")
+	assert.Equal(t, e.Error(), "ConfigFileIsNotReadable{File:app.json,Cause:no such file}")
+	assert.Equal(t, e.Unwrap(), e0)
+	assert.True(t, goerrors.Is(e, e0))
+}
+
+func TestErrors_ConfigFileIsInvalid(t *testing.T) {
+	e0 := fmt.Errorf("bad syntax")
+	e := errors.ConfigFileIsInvalid{File: "app.ini", Line: 3, Cause: e0}
+	assert.Equal(t, e.File, "app.ini")
+	assert.Equal(t, e.Line, 3)
+	assert.Equal(t, e.Error(), "ConfigFileIsInvalid{File:app.ini,Line:3,Cause:bad syntax}")
+	assert.Equal(t, e.Unwrap(), e0)
+	assert.True(t, goerrors.Is(e, e0))
+}
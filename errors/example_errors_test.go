@@ -119,6 +119,71 @@ func ExampleOptionArgIsInvalid_Unwrap() {
 	// true
 }
 
+func ExampleConfigFileIsInvalid_Error() {
+	e := errors.ConfigFileIsInvalid{
+		File:  "path/to/app.ini",
+		Line:  3,
+		Cause: fmt.Errorf("no \"=\" after key"),
+	}
+
+	fmt.Printf("%s\n", e.Error())
+	// Output:
+	// ConfigFileIsInvalid{File:path/to/app.ini,Line:3,Cause:no "=" after key}
+}
+
+func ExampleConfigFileIsNotReadable_Error() {
+	e := errors.ConfigFileIsNotReadable{
+		File:  "path/to/app.json",
+		Cause: fmt.Errorf("permission denied"),
+	}
+
+	fmt.Printf("%s\n", e.Error())
+	// Output:
+	// ConfigFileIsNotReadable{File:path/to/app.json,Cause:permission denied}
+}
+
+func ExampleErrorList_Error() {
+	e := errors.ErrorList{Errs: []error{
+		errors.UnconfiguredOption{Option: "foo"},
+		errors.OptionNeedsArg{Option: "bar", StoreKey: "Bar"},
+	}}
+
+	fmt.Printf("%s\n", e.Error())
+	// Output:
+	// ErrorList{Errs:[UnconfiguredOption{Option:foo} OptionNeedsArg{Option:bar,StoreKey:Bar}]}
+}
+
+func ExampleErrorList_As() {
+	// import ( goerrors "errors" )
+
+	e := errors.ErrorList{Errs: []error{
+		errors.UnconfiguredOption{Option: "foo"},
+		errors.OptionNeedsArg{Option: "bar", StoreKey: "Bar"},
+	}}
+
+	var e0 errors.OptionNeedsArg
+	fmt.Printf("%t\n", goerrors.As(e, &e0))
+	fmt.Printf("%s\n", e0.Option)
+	// Output:
+	// true
+	// bar
+}
+
+func ExampleErrorList_Is() {
+	// import ( goerrors "errors" )
+
+	e := errors.ErrorList{Errs: []error{
+		errors.UnconfiguredOption{Option: "foo"},
+		errors.OptionNeedsArg{Option: "bar", StoreKey: "Bar"},
+	}}
+
+	fmt.Printf("%t\n", goerrors.Is(e, errors.UnconfiguredOption{Option: "foo"}))
+	fmt.Printf("%t\n", goerrors.Is(e, errors.UnconfiguredOption{Option: "baz"}))
+	// Output:
+	// true
+	// false
+}
+
 func ExampleOptionIsNotArray_Error() {
 	e := errors.OptionIsNotArray{
 		Option:   "foo-bar",
@@ -279,3 +344,35 @@ func ExampleUnconfiguredOption_GetOption() {
 	// foo-bar
 	// foo-bar
 }
+
+func ExampleUnconfiguredSubCmd_Error() {
+	e := errors.UnconfiguredSubCmd{
+		Name: "foo-bar",
+	}
+
+	fmt.Printf("%s\n", e.Error())
+	// Output:
+	// UnconfiguredSubCmd{Name:foo-bar}
+}
+
+func ExampleMessage() {
+	e := errors.OptionNeedsArg{Option: "foo-bar", StoreKey: "FooBar"}
+
+	fmt.Println(errors.Message(e))
+	// Output:
+	// option '--foo-bar' requires an argument
+}
+
+func ExampleSetMessage() {
+	e := errors.SetMessage("fr", errors.OptionNeedsArg{},
+		"l'option '{{opt .Option}}' nécessite un argument")
+	fmt.Printf("e = %v\n", e)
+
+	err := errors.OptionNeedsArg{Option: "foo-bar", StoreKey: "FooBar"}
+	fmt.Println(errors.MessageIn("fr", err))
+	fmt.Println(errors.MessageIn("en", err))
+	// Output:
+	// e = <nil>
+	// l'option '--foo-bar' nécessite un argument
+	// option '--foo-bar' requires an argument
+}
@@ -0,0 +1,242 @@
+// Copyright (C) 2026 Takayuki Sato. All Rights Reserved.
+// This program is free software under MIT License.
+// See the file LICENSE in this distribution for more details.
+
+package errors
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+	"text/template"
+)
+
+// DefaultLang is the language of the built-in message templates, and is used when no message
+// template is set for the current language.
+const DefaultLang = "en"
+
+var msgFuncs = template.FuncMap{
+	"opt":      optWithHyphens,
+	"causeMsg": func(error) string { return "" }, // replaced in MessageIn for the language.
+}
+
+var (
+	msgMutex   sync.RWMutex
+	msgLang    = DefaultLang
+	msgTmplMap = map[string]map[reflect.Type]*template.Template{}
+)
+
+func init() {
+	defaultTmpls := []struct {
+		err  error
+		tmpl string
+	}{
+		{OptionContainsInvalidChar{},
+			"option '{{opt .Option}}' contains an invalid character"},
+		{UnconfiguredOption{},
+			"unknown option '{{opt .Option}}'" +
+				"{{if .Suggestions}} (did you mean " +
+				"{{range $i, $s := .Suggestions}}{{if $i}}, {{end}}'{{opt $s}}'{{end}}?){{end}}"},
+		{UnconfiguredSubCmd{},
+			"unknown command '{{.Name}}'" +
+				"{{if .Suggestions}} (did you mean " +
+				"{{range $i, $s := .Suggestions}}{{if $i}}, {{end}}'{{$s}}'{{end}}?){{end}}"},
+		{OptionNeedsArg{},
+			"option '{{opt .Option}}' requires an argument"},
+		{OptionTakesNoArg{},
+			"option '{{opt .Option}}' does not take an argument"},
+		{OptionIsNotArray{},
+			"option '{{opt .Option}}' cannot take multiple arguments"},
+		{StoreKeyIsDuplicated{},
+			"store key '{{.StoreKey}}' of option '{{opt .Name}}' is duplicated"},
+		{ConfigIsArrayButHasNoArg{},
+			"option '{{opt .Name}}' is configured as an array but takes no argument"},
+		{ConfigHasDefaultsButHasNoArg{},
+			"option '{{opt .Name}}' is configured with default values but takes no argument"},
+		{ConfigIsMapButHasNoArg{},
+			"option '{{opt .Name}}' is configured as a map but takes no argument"},
+		{OptionNameIsDuplicated{},
+			"option name '{{opt .Name}}' is duplicated"},
+		{OptionArgIsInvalid{},
+			"invalid argument '{{.OptArg}}' for option '{{opt .Option}}'" +
+				"{{with .Cause}}: {{causeMsg .}}{{end}}"},
+		{OptionStoreIsNotChangeable{},
+			"option store is not a pointer"},
+		{BadFieldType{},
+			"field '{{.Field}}' for option '{{opt .Option}}' has an unsupported type: {{.Type}}"},
+		{ConfigFileIsNotReadable{},
+			"cannot read configuration file '{{.File}}': {{.Cause}}"},
+		{EnvVarIsInvalid{},
+			"invalid value '{{.Value}}' of environment variable '{{.EnvVar}}' " +
+				"for option '{{opt .Option}}'{{with .Cause}}: {{causeMsg .}}{{end}}"},
+		{ConfigValueIsInvalid{},
+			"invalid value '{{.Value}}' of key '{{.Key}}' in configuration file " +
+				"for option '{{opt .Option}}'{{with .Cause}}: {{causeMsg .}}{{end}}"},
+		{MissingRequiredOption{},
+			"{{if eq (len .Options) 1}}required option{{else}}required options{{end}} " +
+				"{{range $i, $o := .Options}}{{if $i}}, {{end}}'{{opt $o}}'{{end}} " +
+				"{{if eq (len .Options) 1}}is{{else}}are{{end}} missing"},
+		{OptionsAreExclusive{},
+			"options {{range $i, $o := .Options}}{{if $i}}, {{end}}'{{opt $o}}'{{end}} " +
+				"cannot be used together"},
+		{OptionGroupIsMissing{},
+			"one of options {{range $i, $o := .Options}}{{if $i}}, {{end}}'{{opt $o}}'{{end}} " +
+				"is required"},
+		{OptionRequiresOthers{},
+			"option '{{opt .Option}}' requires " +
+				"{{range $i, $o := .Missing}}{{if $i}}, {{end}}'{{opt $o}}'{{end}}"},
+		{HelpIsRequested{},
+			"help is requested by option '{{opt .Option}}'"},
+		{VersionIsRequested{},
+			"version is requested by option '{{opt .Option}}'"},
+		{MissingCmdArg{},
+			"missing argument '{{.Name}}'"},
+		{TooManyCmdArgs{},
+			"too many arguments: {{range $i, $a := .Args}}{{if $i}}, {{end}}'{{$a}}'{{end}}"},
+		{CmdArgIsInvalid{},
+			"invalid argument '{{.Arg}}' for '{{.Name}}'{{with .Cause}}: {{causeMsg .}}{{end}}"},
+		{ArgPosIsInvalid{},
+			"field '{{.Field}}' has an invalid argument position: {{.Pos}}"},
+		{OptArgIsNotKeyValue{},
+			"argument '{{.OptArg}}' is not in the form of key=value"},
+		{MapKeyIsDuplicated{},
+			"key '{{.Key}}' of option '{{opt .Option}}' is duplicated"},
+		{ConfigFileIsInvalid{},
+			"invalid configuration file '{{.File}}'{{if .Line}} at line {{.Line}}{{end}}: {{.Cause}}"},
+	}
+
+	for _, d := range defaultTmpls {
+		e := SetMessage(DefaultLang, d.err, d.tmpl)
+		if e != nil {
+			panic(e)
+		}
+	}
+}
+
+// optWithHyphens is the function that adds hyphens to an option name, like -o or --option.
+// If the name contains characters which are not allowed in an option name, for example, a store
+// key joined with dots, the name is returned as it is.
+func optWithHyphens(name string) string {
+	if len(name) == 0 {
+		return name
+	}
+	for _, r := range name {
+		if !(('a' <= r && r <= 'z') || ('A' <= r && r <= 'Z') || ('0' <= r && r <= '9') || r == '-') {
+			return name
+		}
+	}
+	if len(name) == 1 {
+		return "-" + name
+	}
+	return "--" + name
+}
+
+// SetLang is the function to set the language used by Message.
+func SetLang(lang string) {
+	msgMutex.Lock()
+	defer msgMutex.Unlock()
+	msgLang = lang
+}
+
+// SetMessage is the function to set a message template for the type of the specified error value
+// in the specified language.
+// An existing template for the same type and language, including the built-in one, is replaced.
+//
+// The template is written in the syntax of text/template, and is executed with the error value
+// as its data, so the fields of the error can be referred like {{.Option}}.
+// In addition, the template function opt is available, which adds hyphens to an option name, like
+// {{opt .Option}}, and the template function causeMsg is available, which renders the message of a
+// cause error in the same language, like {{with .Cause}}: {{causeMsg .}}{{end}}.
+// causeMsg skips an OptionArgIsInvalid error which wraps another cause, since the message of the
+// error having it tells the invalid argument already.
+//
+// If the template cannot be parsed, this function returns the error.
+func SetMessage(lang string, errValue error, tmpl string) error {
+	t, e := template.New(lang).Funcs(msgFuncs).Parse(tmpl)
+	if e != nil {
+		return e
+	}
+
+	msgMutex.Lock()
+	defer msgMutex.Unlock()
+
+	m, exists := msgTmplMap[lang]
+	if !exists {
+		m = make(map[reflect.Type]*template.Template)
+		msgTmplMap[lang] = m
+	}
+	m[reflect.TypeOf(errValue)] = t
+	return nil
+}
+
+// Message is the function to retrieve a human-readable message of the specified error in the
+// language set by SetLang.
+// See MessageIn about how a message is rendered.
+func Message(err error) string {
+	msgMutex.RLock()
+	lang := msgLang
+	msgMutex.RUnlock()
+
+	return MessageIn(lang, err)
+}
+
+// MessageIn is the function to retrieve a human-readable message of the specified error in the
+// specified language.
+//
+// The message is rendered with the template set for the type of the error in the language.
+// If no template is set in the language, the template in DefaultLang is used, and if it is not
+// set either, the result of the Error method of the error is returned.
+// In case of ErrorList, the messages of the errors in the list are joined with newlines.
+func MessageIn(lang string, err error) string {
+	if err == nil {
+		return ""
+	}
+
+	if list, ok := err.(ErrorList); ok {
+		msgs := make([]string, len(list.Errs))
+		for i, e := range list.Errs {
+			msgs[i] = MessageIn(lang, e)
+		}
+		return strings.Join(msgs, "\n")
+	}
+
+	t := findMessageTmpl(lang, reflect.TypeOf(err))
+	if t == nil {
+		return err.Error()
+	}
+
+	t, e := t.Clone()
+	if e != nil {
+		return err.Error()
+	}
+	t.Funcs(template.FuncMap{
+		"causeMsg": func(cause error) string { return causeMessageIn(lang, cause) },
+	})
+
+	var b strings.Builder
+	if t.Execute(&b, err) != nil {
+		return err.Error()
+	}
+	return b.String()
+}
+
+func causeMessageIn(lang string, cause error) string {
+	for {
+		e, ok := cause.(OptionArgIsInvalid)
+		if !ok || e.Cause == nil {
+			break
+		}
+		cause = e.Cause
+	}
+	return MessageIn(lang, cause)
+}
+
+func findMessageTmpl(lang string, typ reflect.Type) *template.Template {
+	msgMutex.RLock()
+	defer msgMutex.RUnlock()
+
+	if t, exists := msgTmplMap[lang][typ]; exists {
+		return t
+	}
+	return msgTmplMap[DefaultLang][typ]
+}
@@ -0,0 +1,220 @@
+package errors_test
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/sttk/cliargs/errors"
+)
+
+func TestMessage_defaultTemplates(t *testing.T) {
+	cause := fmt.Errorf("bad")
+
+	assert.Equal(t, errors.Message(errors.OptionContainsInvalidChar{Option: "f$o"}),
+		"option 'f$o' contains an invalid character")
+	assert.Equal(t, errors.Message(errors.UnconfiguredOption{Option: "foo"}),
+		"unknown option '--foo'")
+	assert.Equal(t, errors.Message(errors.UnconfiguredOption{Option: "f"}),
+		"unknown option '-f'")
+	assert.Equal(t, errors.Message(errors.UnconfiguredSubCmd{Name: "foo"}),
+		"unknown command 'foo'")
+	assert.Equal(t, errors.Message(errors.OptionNeedsArg{Option: "foo", StoreKey: "Foo"}),
+		"option '--foo' requires an argument")
+	assert.Equal(t, errors.Message(errors.OptionTakesNoArg{Option: "foo", StoreKey: "Foo"}),
+		"option '--foo' does not take an argument")
+	assert.Equal(t, errors.Message(errors.OptionIsNotArray{Option: "foo", StoreKey: "Foo"}),
+		"option '--foo' cannot take multiple arguments")
+	assert.Equal(t, errors.Message(errors.StoreKeyIsDuplicated{StoreKey: "Foo", Name: "foo"}),
+		"store key 'Foo' of option '--foo' is duplicated")
+	assert.Equal(t, errors.Message(errors.ConfigIsArrayButHasNoArg{StoreKey: "Foo", Name: "foo"}),
+		"option '--foo' is configured as an array but takes no argument")
+	assert.Equal(t,
+		errors.Message(errors.ConfigHasDefaultsButHasNoArg{StoreKey: "Foo", Name: "foo"}),
+		"option '--foo' is configured with default values but takes no argument")
+	assert.Equal(t, errors.Message(errors.OptionNameIsDuplicated{StoreKey: "Foo", Name: "foo"}),
+		"option name '--foo' is duplicated")
+	assert.Equal(t, errors.Message(errors.OptionArgIsInvalid{
+		StoreKey: "Foo", Option: "foo", OptArg: "x", TypeKind: reflect.Int, Cause: cause}),
+		"invalid argument 'x' for option '--foo': bad")
+	assert.Equal(t, errors.Message(errors.OptionArgIsInvalid{
+		StoreKey: "Foo", Option: "APP_FOO", OptArg: "x", TypeKind: reflect.Int, Cause: cause}),
+		"invalid argument 'x' for option 'APP_FOO': bad")
+	assert.Equal(t, errors.Message(errors.OptionStoreIsNotChangeable{}),
+		"option store is not a pointer")
+	assert.Equal(t, errors.Message(errors.BadFieldType{
+		Option: "foo", Field: "Foo", Type: reflect.TypeOf(0)}),
+		"field 'Foo' for option '--foo' has an unsupported type: int")
+	assert.Equal(t, errors.Message(errors.ConfigFileIsNotReadable{File: "a.json", Cause: cause}),
+		"cannot read configuration file 'a.json': bad")
+	assert.Equal(t, errors.Message(errors.ConfigFileIsInvalid{File: "a.json", Cause: cause}),
+		"invalid configuration file 'a.json': bad")
+	assert.Equal(t, errors.Message(errors.ConfigFileIsInvalid{
+		File: "a.ini", Line: 3, Cause: cause}),
+		"invalid configuration file 'a.ini' at line 3: bad")
+}
+
+func TestMessage_suggestions(t *testing.T) {
+	assert.Equal(t, errors.Message(errors.NewUnconfiguredOption(
+		"verbsoe", []string{"verbose"})),
+		"unknown option '--verbsoe' (did you mean '--verbose'?)")
+	assert.Equal(t, errors.Message(errors.NewUnconfiguredOption(
+		"ver", []string{"verbose", "version"})),
+		"unknown option '--ver' (did you mean '--verbose', '--version'?)")
+	assert.Equal(t, errors.Message(errors.NewUnconfiguredSubCmd(
+		"stat", []string{"status"})),
+		"unknown command 'stat' (did you mean 'status'?)")
+}
+
+func TestMessage_missingRequiredOption(t *testing.T) {
+	assert.Equal(t, errors.Message(errors.MissingRequiredOption{Options: []string{"foo"}}),
+		"required option '--foo' is missing")
+	assert.Equal(t, errors.Message(errors.MissingRequiredOption{Options: []string{"foo", "b"}}),
+		"required options '--foo', '-b' are missing")
+}
+
+func TestMessage_optionRelations(t *testing.T) {
+	assert.Equal(t, errors.Message(errors.OptionsAreExclusive{
+		Group: "format", Options: []string{"json", "y"}}),
+		"options '--json', '-y' cannot be used together")
+	assert.Equal(t, errors.Message(errors.OptionGroupIsMissing{
+		Group: "in", Options: []string{"file", "stdin"}}),
+		"one of options '--file', '--stdin' is required")
+	assert.Equal(t, errors.Message(errors.OptionRequiresOthers{
+		Option: "user", StoreKey: "User", Missing: []string{"password", "p"}}),
+		"option '--user' requires '--password', '-p'")
+}
+
+func TestMessage_helpAndVersion(t *testing.T) {
+	assert.Equal(t, errors.Message(errors.HelpIsRequested{Option: "help"}),
+		"help is requested by option '--help'")
+	assert.Equal(t, errors.Message(errors.VersionIsRequested{Option: "V"}),
+		"version is requested by option '-V'")
+}
+
+func TestMessage_cmdArgs(t *testing.T) {
+	assert.Equal(t, errors.Message(errors.MissingCmdArg{Name: "file"}),
+		"missing argument 'file'")
+	assert.Equal(t, errors.Message(errors.TooManyCmdArgs{Args: []string{"a", "b"}}),
+		"too many arguments: 'a', 'b'")
+	assert.Equal(t, errors.Message(errors.CmdArgIsInvalid{Name: "num", Arg: "x"}),
+		"invalid argument 'x' for 'num'")
+	assert.Equal(t, errors.Message(errors.ArgPosIsInvalid{Field: "Src", Pos: "x"}),
+		"field 'Src' has an invalid argument position: x")
+}
+
+func TestMessage_optArgSources(t *testing.T) {
+	assert.Equal(t, errors.Message(errors.EnvVarIsInvalid{
+		EnvVar: "PORT", Option: "port", StoreKey: "Port", Value: "x"}),
+		"invalid value 'x' of environment variable 'PORT' for option '--port'")
+	assert.Equal(t, errors.Message(errors.ConfigValueIsInvalid{
+		Key: "Port", Option: "port", StoreKey: "Port", Value: "1,2"}),
+		"invalid value '1,2' of key 'Port' in configuration file for option '--port'")
+}
+
+func TestMessage_causes(t *testing.T) {
+	notKeyValue := errors.OptArgIsNotKeyValue{OptArg: "env"}
+	assert.Equal(t, errors.Message(errors.OptionArgIsInvalid{
+		StoreKey: "Label", Option: "l", OptArg: "env", TypeKind: reflect.Map, Cause: notKeyValue}),
+		"invalid argument 'env' for option '-l': argument 'env' is not in the form of key=value")
+	assert.Equal(t, errors.Message(errors.EnvVarIsInvalid{
+		EnvVar: "LABEL", Option: "l", StoreKey: "Label", Value: "env",
+		Cause: errors.OptionArgIsInvalid{
+			StoreKey: "Label", Option: "l", OptArg: "env", TypeKind: reflect.Map,
+			Cause: notKeyValue}}),
+		"invalid value 'env' of environment variable 'LABEL' for option '-l': "+
+			"argument 'env' is not in the form of key=value")
+	assert.Equal(t, errors.Message(errors.ConfigValueIsInvalid{
+		Key: "Label", Option: "l", StoreKey: "Label", Value: "env",
+		Cause: errors.OptionArgIsInvalid{StoreKey: "Label", Option: "l", OptArg: "env"}}),
+		"invalid value 'env' of key 'Label' in configuration file for option '-l': "+
+			"invalid argument 'env' for option '-l'")
+	assert.Equal(t, errors.Message(errors.CmdArgIsInvalid{
+		Name: "num", Arg: "x", Cause: fmt.Errorf("not a number")}),
+		"invalid argument 'x' for 'num': not a number")
+
+	e := errors.SetMessage("ja", errors.OptArgIsNotKeyValue{},
+		"'{{.OptArg}}' は key=value 形式ではありません")
+	assert.Nil(t, e)
+	assert.Equal(t, errors.MessageIn("ja", errors.OptionArgIsInvalid{
+		StoreKey: "Label", Option: "l", OptArg: "env", TypeKind: reflect.Map, Cause: notKeyValue}),
+		"invalid argument 'env' for option '-l': 'env' は key=value 形式ではありません")
+}
+
+func TestMessage_mapOptions(t *testing.T) {
+	assert.Equal(t, errors.Message(errors.ConfigIsMapButHasNoArg{StoreKey: "Foo", Name: "foo"}),
+		"option '--foo' is configured as a map but takes no argument")
+	assert.Equal(t, errors.Message(errors.OptArgIsNotKeyValue{OptArg: "env"}),
+		"argument 'env' is not in the form of key=value")
+	assert.Equal(t, errors.Message(errors.MapKeyIsDuplicated{
+		StoreKey: "Label", Option: "l", Key: "env"}),
+		"key 'env' of option '-l' is duplicated")
+}
+
+func TestMessage_errorList(t *testing.T) {
+	e := errors.ErrorList{Errs: []error{
+		errors.UnconfiguredOption{Option: "foo"},
+		errors.OptionNeedsArg{Option: "b", StoreKey: "bar"},
+	}}
+	assert.Equal(t, errors.Message(e), "unknown option '--foo'\noption '-b' requires an argument")
+}
+
+func TestMessage_unknownError(t *testing.T) {
+	_, e := strconv.Atoi("x")
+	assert.Equal(t, errors.Message(e), e.Error())
+	assert.Equal(t, errors.Message(nil), "")
+}
+
+func TestMessageIn_customTemplate(t *testing.T) {
+	e := errors.SetMessage("ja", errors.OptionNeedsArg{},
+		"オプション '{{opt .Option}}' には引数が必要です")
+	assert.Nil(t, e)
+
+	err := errors.OptionNeedsArg{Option: "foo", StoreKey: "Foo"}
+	assert.Equal(t, errors.MessageIn("ja", err), "オプション '--foo' には引数が必要です")
+	assert.Equal(t, errors.MessageIn("en", err), "option '--foo' requires an argument")
+
+	// falls back to the default language
+	assert.Equal(t, errors.MessageIn("ja", errors.UnconfiguredOption{Option: "foo"}),
+		"unknown option '--foo'")
+	assert.Equal(t, errors.MessageIn("fr", err), "option '--foo' requires an argument")
+}
+
+func TestMessage_setLang(t *testing.T) {
+	e := errors.SetMessage("de", errors.UnconfiguredOption{}, "unbekannte Option '{{opt .Option}}'")
+	assert.Nil(t, e)
+
+	errors.SetLang("de")
+	defer errors.SetLang(errors.DefaultLang)
+
+	assert.Equal(t, errors.Message(errors.UnconfiguredOption{Option: "foo"}),
+		"unbekannte Option '--foo'")
+	assert.Equal(t, errors.Message(errors.UnconfiguredSubCmd{Name: "foo"}),
+		"unknown command 'foo'")
+}
+
+func TestSetMessage_overrideDefault(t *testing.T) {
+	e := errors.SetMessage(errors.DefaultLang, errors.UnconfiguredSubCmd{},
+		"no such command: {{.Name}}")
+	assert.Nil(t, e)
+	defer errors.SetMessage(errors.DefaultLang, errors.UnconfiguredSubCmd{},
+		"unknown command '{{.Name}}'")
+
+	assert.Equal(t, errors.Message(errors.UnconfiguredSubCmd{Name: "foo"}), "no such command: foo")
+}
+
+func TestSetMessage_badTemplate(t *testing.T) {
+	e := errors.SetMessage("xx", errors.UnconfiguredOption{}, "{{.Option")
+	assert.NotNil(t, e)
+	assert.Equal(t, errors.MessageIn("xx", errors.UnconfiguredOption{Option: "foo"}),
+		"unknown option '--foo'")
+}
+
+func TestMessage_templateExecutionFails(t *testing.T) {
+	e := errors.SetMessage("yy", errors.UnconfiguredOption{}, "{{.NoSuchField}}")
+	assert.Nil(t, e)
+	err := errors.UnconfiguredOption{Option: "foo"}
+	assert.Equal(t, errors.MessageIn("yy", err), err.Error())
+}
@@ -0,0 +1,54 @@
+package cliargs_test
+
+import (
+	"fmt"
+
+	"github.com/sttk/cliargs"
+)
+
+func ExampleCmd_Dispatch() {
+	cmdCfg := cliargs.CmdCfg{
+		Name: "app",
+		OptCfgs: []cliargs.OptCfg{
+			cliargs.OptCfg{Names: []string{"verbose", "v"}},
+		},
+		SubCmds: []cliargs.CmdCfg{
+			cliargs.CmdCfg{
+				Name:    "remote",
+				Aliases: []string{"r"},
+				SubCmds: []cliargs.CmdCfg{
+					cliargs.CmdCfg{
+						Name: "add",
+						OptCfgs: []cliargs.OptCfg{
+							cliargs.OptCfg{Names: []string{"track", "t"}, HasArg: true},
+						},
+						Run: func(cmd cliargs.Cmd, parents []cliargs.Cmd) error {
+							fmt.Printf("parents[0].HasOpt(\"verbose\") = %v\n", parents[0].HasOpt("verbose"))
+							fmt.Printf("parents[1].Name = %v\n", parents[1].Name)
+							fmt.Printf("cmd.Name = %v\n", cmd.Name)
+							fmt.Printf("cmd.Args = %v\n", cmd.Args)
+							fmt.Printf("cmd.OptArg(\"track\") = %v\n", cmd.OptArg("track"))
+							return nil
+						},
+					},
+				},
+			},
+		},
+	}
+
+	cmd := cliargs.NewCmdFromArgs("app", []string{"-v", "r", "add", "-t", "main", "origin"})
+	err := cmd.Dispatch(cmdCfg)
+	fmt.Printf("err = %v\n", err)
+
+	cmd = cliargs.NewCmdFromArgs("app", []string{"remote", "rename"})
+	err = cmd.Dispatch(cmdCfg)
+	fmt.Printf("err = %v\n", err)
+	// Output:
+	// parents[0].HasOpt("verbose") = true
+	// parents[1].Name = remote
+	// cmd.Name = add
+	// cmd.Args = [origin]
+	// cmd.OptArg("track") = main
+	// err = <nil>
+	// err = UnconfiguredSubCmd{Name:rename}
+}
@@ -0,0 +1,76 @@
+package cliargs_test
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/sttk/cliargs"
+)
+
+func ExampleMakeBashCompletion() {
+	cmdCfg := cliargs.CmdCfg{
+		Name: "app",
+		OptCfgs: []cliargs.OptCfg{
+			cliargs.OptCfg{Names: []string{"verbose", "v"}, Desc: "Prints logs."},
+		},
+		SubCmds: []cliargs.CmdCfg{
+			cliargs.CmdCfg{Name: "remote", Aliases: []string{"r"}, Desc: "Manages remotes."},
+		},
+	}
+
+	script, err := cliargs.MakeBashCompletion(cmdCfg)
+	fmt.Printf("err = %v\n", err)
+
+	lines := strings.Split(strings.TrimSpace(script), "\n")
+	fmt.Println(lines[0])
+	fmt.Println(lines[len(lines)-1])
+
+	// Output:
+	// err = <nil>
+	// # bash completion for app
+	// complete -F _app_completion app
+}
+
+func ExampleMakeFishCompletion() {
+	cmdCfg := cliargs.CmdCfg{
+		Name: "app",
+		OptCfgs: []cliargs.OptCfg{
+			cliargs.OptCfg{Names: []string{"verbose", "v"}, Desc: "Prints logs."},
+		},
+	}
+
+	script, err := cliargs.MakeFishCompletion(cmdCfg)
+	fmt.Printf("err = %v\n", err)
+
+	lines := strings.Split(strings.TrimSpace(script), "\n")
+	fmt.Println(lines[len(lines)-1])
+
+	// Output:
+	// err = <nil>
+	// complete -c app -f -a '(__app_complete)'
+}
+
+func ExampleCmd_Complete() {
+	profiles := func(prefix string) []string {
+		return []string{"dev", "default", "prod"}
+	}
+
+	cmdCfg := cliargs.CmdCfg{
+		Name: "app",
+		OptCfgs: []cliargs.OptCfg{
+			cliargs.OptCfg{Names: []string{"profile"}, HasArg: true, Completer: &profiles},
+		},
+	}
+
+	cmd := cliargs.NewCmdFromArgs("app", []string{"__complete", "--profile", "d"})
+	isCompletion, err := cmd.Complete(cmdCfg, os.Stdout)
+	fmt.Printf("isCompletion = %v\n", isCompletion)
+	fmt.Printf("err = %v\n", err)
+
+	// Output:
+	// dev
+	// default
+	// isCompletion = true
+	// err = <nil>
+}
@@ -2,6 +2,7 @@ package cliargs_test
 
 import (
 	"fmt"
+	"os"
 
 	"github.com/sttk/cliargs"
 )
@@ -68,3 +69,84 @@ func ExampleHelp_Iter() {
 	//                 Qux is a string.
 	//       --quux    Quux is a string array.
 }
+
+func ExampleHelp_AddUsage() {
+	type MyOptions struct {
+		Verbose bool     `optcfg:"verbose,v" optdesc:"Prints details."`
+		Output  string   `optcfg:"output,o" optreq:"true" optdesc:"Output file." optarg:"<file>"`
+		Src     []string `optpos:"rest" optreq:"true" optarg:"src" optdesc:"Source files."`
+	}
+	options := MyOptions{}
+
+	cmd := cliargs.NewCmdFromArgs("app", []string{"-o", "out.txt", "a.txt"})
+	cmd.ParseFor(&options)
+
+	help := cliargs.NewHelp()
+	help.AddUsage(cmd)
+	help.AddText("ARGS:")
+	help.AddArgsWithMargins(cmd.ArgCfgs, 2, 0)
+	help.AddText("OPTIONS:")
+	help.AddOptsWithMargins(cmd.OptCfgs, 2, 0)
+
+	help.Print()
+
+	// Output:
+	// Usage: app [OPTIONS] --output <file> <src>...
+	// ARGS:
+	//   <src>...  Source files.
+	// OPTIONS:
+	//   --verbose, -v        Prints details.
+	//   --output, -o <file>  Output file. (required)
+}
+
+func ExampleHelp_Markdown() {
+	help := cliargs.NewHelp()
+	help.AddText("Prints greetings.")
+	help.AddOpts([]cliargs.OptCfg{
+		cliargs.OptCfg{Names: []string{"name", "n"}, HasArg: true, ArgInHelp: "<name>",
+			Desc: "The name to greet."},
+	})
+
+	fmt.Print(help.Markdown())
+
+	// Output:
+	// Prints greetings.
+	//
+	// - `--name, -n <name>`: The name to greet.
+}
+
+func ExampleHelp_Roff() {
+	help := cliargs.NewHelp()
+	help.AddText("Prints greetings.")
+	help.AddOpts([]cliargs.OptCfg{
+		cliargs.OptCfg{Names: []string{"name", "n"}, HasArg: true, ArgInHelp: "<name>",
+			Desc: "The name to greet."},
+	})
+
+	fmt.Print(help.Roff("greet", "1"))
+
+	// Output:
+	// .TH "GREET" "1"
+	// .PP
+	// Prints greetings.
+	// .TP
+	// \fB\-\-name, \-n <name>\fR
+	// The name to greet.
+}
+
+func ExampleHelp_Fprint() {
+	help := cliargs.NewHelp()
+	help.AddText("This command prints greetings to the specified name.")
+	help.AddOpts([]cliargs.OptCfg{
+		cliargs.OptCfg{Names: []string{"name", "n"}, HasArg: true, ArgInHelp: "<name>",
+			Desc: "The name to greet."},
+	})
+
+	help.Fprint(os.Stdout, 30)
+
+	// Output:
+	// This command prints greetings
+	// to the specified name.
+	// --name, -n <name>  The name to
+	//                    greet.
+}
@@ -303,3 +303,63 @@ func ExampleMakeOptCfgsFor() {
 
 	reset()
 }
+
+func ExampleMakeOptCfgsFor_nested() {
+	type LogOptions struct {
+		Verbose bool `optcfg:"verbose,v" optdesc:"Verbose mode"`
+	}
+	type DBOptions struct {
+		Host string `optcfg:"host=localhost" optdesc:"Database host"`
+		Port int    `optcfg:"port=5432" optdesc:"Database port"`
+	}
+	type MyOptions struct {
+		LogOptions
+		DB DBOptions `optprefix:"db-"`
+	}
+	options := MyOptions{}
+
+	optCfgs, err := cliargs.MakeOptCfgsFor(&options)
+	fmt.Printf("err = %v\n", err)
+	for _, cfg := range optCfgs {
+		fmt.Printf("StoreKey = %v, Names = %v\n", cfg.StoreKey, cfg.Names)
+	}
+	// Output:
+	// err = <nil>
+	// StoreKey = Verbose, Names = [verbose v]
+	// StoreKey = DB.Host, Names = [db-host]
+	// StoreKey = DB.Port, Names = [db-port]
+}
+
+func ExampleMakeArgCfgsFor() {
+	type MyOptions struct {
+		Verbose bool     `optcfg:"verbose,v"`
+		Src     string   `optpos:"0" optarg:"src" optdesc:"Source file."`
+		Dests   []string `optpos:"rest" optreq:"true" optarg:"dest" optdesc:"Destinations."`
+	}
+	options := MyOptions{}
+
+	argCfgs, err := cliargs.MakeArgCfgsFor(&options)
+	fmt.Printf("err = %v\n", err)
+	fmt.Printf("len(argCfgs) = %v\n", len(argCfgs))
+	fmt.Printf("argCfgs[0] = {Name:%s Min:%d Max:%d Desc:%s}\n",
+		argCfgs[0].Name, argCfgs[0].Min, argCfgs[0].Max, argCfgs[0].Desc)
+	fmt.Printf("argCfgs[1] = {Name:%s Min:%d Max:%d Desc:%s}\n",
+		argCfgs[1].Name, argCfgs[1].Min, argCfgs[1].Max, argCfgs[1].Desc)
+
+	cmd := cliargs.NewCmdFromArgs("app", []string{"-v", "a.txt", "b", "c"})
+	err = cmd.ParseFor(&options)
+	fmt.Printf("err = %v\n", err)
+	fmt.Printf("options.Src = %v\n", options.Src)
+	fmt.Printf("options.Dests = %v\n", options.Dests)
+	fmt.Printf("cmd.CmdArgs(\"dest\") = %v\n", cmd.CmdArgs("dest"))
+
+	// Output:
+	// err = <nil>
+	// len(argCfgs) = 2
+	// argCfgs[0] = {Name:src Min:1 Max:1 Desc:Source file.}
+	// argCfgs[1] = {Name:dest Min:1 Max:-1 Desc:Destinations.}
+	// err = <nil>
+	// options.Src = a.txt
+	// options.Dests = [b c]
+	// cmd.CmdArgs("dest") = [b c]
+}
@@ -5,6 +5,7 @@ import (
 	"os"
 
 	"github.com/sttk/cliargs"
+	"github.com/sttk/cliargs/errors"
 )
 
 func ExampleCmd_ParseWith() {
@@ -142,3 +143,31 @@ func ExampleCmd_ParseUntilSubCmdWith() {
 
 	reset()
 }
+
+func ExampleCmd_ParseWith_helpAndVersion() {
+	optCfgs := []cliargs.OptCfg{
+		cliargs.OptCfg{Names: []string{"help", "h"}, IsHelp: true, Desc: "Shows this help."},
+		cliargs.OptCfg{Names: []string{"version"}, IsVersion: true, Desc: "Shows the version."},
+		cliargs.OptCfg{Names: []string{"file"}, HasArg: true, Required: true, ArgInHelp: "<path>",
+			Desc: "The input file."},
+	}
+
+	cmd := cliargs.NewCmdFromArgs("app", []string{"--help"})
+	err := cmd.ParseWith(optCfgs)
+
+	switch err.(type) {
+	case errors.HelpIsRequested:
+		help := cliargs.NewHelp()
+		help.AddUsage(cmd)
+		help.AddOpts(cmd.OptCfgs)
+		help.Print()
+	case errors.VersionIsRequested:
+		fmt.Println("app 1.0.0")
+	}
+
+	// Output:
+	// Usage: app [OPTIONS] --file <path>
+	// --help, -h     Shows this help.
+	// --version      Shows the version.
+	// --file <path>  The input file. (required)
+}
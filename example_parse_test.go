@@ -94,3 +94,36 @@ func ExampleCmd_ParseUntilSubCmd() {
 
 	reset()
 }
+
+func ExampleNewCmdFromArgs() {
+	cmd := cliargs.NewCmdFromArgs("app", []string{"--foo-bar=A", "-a", "qux"})
+	err := cmd.Parse()
+	fmt.Printf("err = %v\n", err)
+	fmt.Printf("cmd.Name = %s\n", cmd.Name)
+	fmt.Printf("cmd.Args = %v\n", cmd.Args)
+	fmt.Printf("cmd.HasOpt(\"a\") = %v\n", cmd.HasOpt("a"))
+	fmt.Printf("cmd.OptArg(\"foo-bar\") = %v\n", cmd.OptArg("foo-bar"))
+	// Output:
+	// err = <nil>
+	// cmd.Name = app
+	// cmd.Args = [qux]
+	// cmd.HasOpt("a") = true
+	// cmd.OptArg("foo-bar") = A
+}
+
+func ExampleCmd_ResetArgs() {
+	cmd := cliargs.NewCmdFromArgs("app", []string{"--foo-bar=A", "qux"})
+	cmd.Parse()
+
+	cmd.ResetArgs([]string{"-a", "quux"})
+	err := cmd.Parse()
+	fmt.Printf("err = %v\n", err)
+	fmt.Printf("cmd.Args = %v\n", cmd.Args)
+	fmt.Printf("cmd.HasOpt(\"a\") = %v\n", cmd.HasOpt("a"))
+	fmt.Printf("cmd.HasOpt(\"foo-bar\") = %v\n", cmd.HasOpt("foo-bar"))
+	// Output:
+	// err = <nil>
+	// cmd.Args = [quux]
+	// cmd.HasOpt("a") = true
+	// cmd.HasOpt("foo-bar") = false
+}
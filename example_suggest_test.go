@@ -0,0 +1,19 @@
+package cliargs_test
+
+import (
+	"fmt"
+
+	"github.com/sttk/cliargs"
+)
+
+func ExampleSuggest() {
+	names := []string{"verbose", "version", "config"}
+
+	fmt.Println(cliargs.Suggest("verbsoe", names))
+	fmt.Println(cliargs.Suggest("ver", names))
+	fmt.Println(cliargs.Suggest("quux", names))
+	// Output:
+	// [verbose]
+	// [verbose version]
+	// []
+}
@@ -0,0 +1,218 @@
+// Copyright (C) 2026 Takayuki Sato. All Rights Reserved.
+// This program is free software under MIT License.
+// See the file LICENSE in this distribution for more details.
+
+package cliargs
+
+import (
+	"html"
+	"strings"
+)
+
+// helpRenderer is the interface for the renderers of help texts in formats other than terminal
+// lines.
+// Indents and margins of blocks are ignored by these renderers because they are for a terminal.
+type helpRenderer interface {
+	text(text string) string
+	title(title string) string
+	usage(usage string) string
+	list(entries []blockBody) string
+}
+
+// render is the function that renders the blocks of a Help instance with the specified renderer,
+// and joins the rendered elements with the separator.
+// Consecutive entries of options or command arguments in a block are rendered as one list.
+func render(help Help, r helpRenderer, sep string) string {
+	var elems []string
+
+	for _, b := range help.blocks {
+		var entries []blockBody
+		for _, body := range b.bodies {
+			if body.kind == entryBody {
+				entries = append(entries, body)
+				continue
+			}
+			if len(entries) > 0 {
+				elems = append(elems, r.list(entries))
+				entries = nil
+			}
+			switch body.kind {
+			case titleBody:
+				elems = append(elems, r.title(body.text))
+			case usageBody:
+				elems = append(elems, r.usage(body.text))
+			default:
+				elems = append(elems, r.text(body.text))
+			}
+		}
+		if len(entries) > 0 {
+			elems = append(elems, r.list(entries))
+		}
+	}
+
+	if len(elems) == 0 {
+		return ""
+	}
+	return strings.Join(elems, sep) + "\n"
+}
+
+// Markdown is a method which renders the help texts of this Help instance in Markdown.
+//
+// A text is rendered as a paragraph of which line breaks are kept, the title of a section added
+// by AddOptGroups is rendered as a heading, and a usage line added by AddUsage is rendered as a
+// code block.
+// OptCfg(s) and ArgCfg(s) are rendered as a list of which items are the option names or the
+// argument names in code spans followed by their descriptions.
+func (help Help) Markdown() string {
+	return render(help, markdownRenderer{}, "\n\n")
+}
+
+type markdownRenderer struct{}
+
+var markdownEscaper = strings.NewReplacer(
+	"\\", "\\\\", "`", "\\`", "*", "\\*", "_", "\\_", "[", "\\[", "]", "\\]",
+	"<", "\\<", ">", "\\>", "#", "\\#", "|", "\\|",
+)
+
+func (markdownRenderer) lines(text string) string {
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = markdownEscaper.Replace(line)
+	}
+	return strings.Join(lines, "\\\n")
+}
+
+func (r markdownRenderer) text(text string) string {
+	return r.lines(text)
+}
+
+func (r markdownRenderer) title(title string) string {
+	return "### " + markdownEscaper.Replace(title)
+}
+
+func (markdownRenderer) usage(usage string) string {
+	return "```\n" + usage + "\n```"
+}
+
+func (r markdownRenderer) list(entries []blockBody) string {
+	items := make([]string, len(entries))
+	for i, entry := range entries {
+		item := "- `" + entry.term + "`"
+		if len(entry.desc) > 0 {
+			item += ": " + strings.ReplaceAll(r.lines(entry.desc), "\n", "\n  ")
+		}
+		items[i] = item
+	}
+	return strings.Join(items, "\n")
+}
+
+// Roff is a method which renders the help texts of this Help instance as a man page in roff.
+// The name and the section are output in the title line (.TH) of the man page, like
+// .TH APP 1.
+//
+// A text is rendered as a paragraph (.PP) of which line breaks are kept, the title of a section
+// added by AddOptGroups is rendered as a subheading (.SS), and a usage line added by AddUsage is
+// rendered without filling (.nf).
+// OptCfg(s) and ArgCfg(s) are rendered as tagged paragraphs (.TP) of which tags are the option
+// names or the argument names in bold.
+func (help Help) Roff(name, section string) string {
+	th := ".TH " + roffQuote(strings.ToUpper(name)) + " " + roffQuote(section)
+	body := render(help, roffRenderer{}, "\n")
+	if len(body) == 0 {
+		return th + "\n"
+	}
+	return th + "\n" + body
+}
+
+type roffRenderer struct{}
+
+var roffEscaper = strings.NewReplacer("\\", "\\e", "-", "\\-")
+
+func roffEscape(line string) string {
+	line = roffEscaper.Replace(line)
+	if strings.HasPrefix(line, ".") || strings.HasPrefix(line, "'") {
+		line = "\\&" + line
+	}
+	return line
+}
+
+func roffQuote(s string) string {
+	return "\"" + strings.ReplaceAll(roffEscaper.Replace(s), "\"", "\\(dq") + "\""
+}
+
+func (roffRenderer) lines(text string) string {
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = roffEscape(line)
+	}
+	return strings.Join(lines, "\n.br\n")
+}
+
+func (r roffRenderer) text(text string) string {
+	return ".PP\n" + r.lines(text)
+}
+
+func (roffRenderer) title(title string) string {
+	return ".SS " + roffQuote(title)
+}
+
+func (r roffRenderer) usage(usage string) string {
+	return ".PP\n.nf\n" + roffEscape(usage) + "\n.fi"
+}
+
+func (r roffRenderer) list(entries []blockBody) string {
+	items := make([]string, len(entries))
+	for i, entry := range entries {
+		item := ".TP\n\\fB" + roffEscaper.Replace(entry.term) + "\\fR"
+		if len(entry.desc) > 0 {
+			item += "\n" + r.lines(entry.desc)
+		}
+		items[i] = item
+	}
+	return strings.Join(items, "\n")
+}
+
+// HTML is a method which renders the help texts of this Help instance as an HTML fragment, which
+// can be embedded in an HTML document.
+//
+// A text is rendered as a paragraph (<p>) of which line breaks are kept, the title of a section
+// added by AddOptGroups is rendered as a heading (<h3>), and a usage line added by AddUsage is
+// rendered as a preformatted text (<pre>).
+// OptCfg(s) and ArgCfg(s) are rendered as a description list (<dl>) of which terms are the option
+// names or the argument names in <code>.
+func (help Help) HTML() string {
+	return render(help, htmlRenderer{}, "\n")
+}
+
+type htmlRenderer struct{}
+
+func (htmlRenderer) lines(text string) string {
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = html.EscapeString(line)
+	}
+	return strings.Join(lines, "<br>\n")
+}
+
+func (r htmlRenderer) text(text string) string {
+	return "<p>" + r.lines(text) + "</p>"
+}
+
+func (htmlRenderer) title(title string) string {
+	return "<h3>" + html.EscapeString(title) + "</h3>"
+}
+
+func (htmlRenderer) usage(usage string) string {
+	return "<pre>" + html.EscapeString(usage) + "</pre>"
+}
+
+func (r htmlRenderer) list(entries []blockBody) string {
+	var b strings.Builder
+	b.WriteString("<dl>\n")
+	for _, entry := range entries {
+		b.WriteString("<dt><code>" + html.EscapeString(entry.term) + "</code></dt>\n")
+		b.WriteString("<dd>" + r.lines(entry.desc) + "</dd>\n")
+	}
+	b.WriteString("</dl>")
+	return b.String()
+}
@@ -0,0 +1,127 @@
+package cliargs_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sttk/cliargs"
+)
+
+func TestHelp_Markdown(t *testing.T) {
+	cmd := cliargs.NewCmdFromArgs("app", []string{})
+	cmd.OptCfgs = []cliargs.OptCfg{
+		cliargs.OptCfg{Names: []string{"verbose", "v"}, Desc: "Prints *all* details.\nFor debug."},
+		cliargs.OptCfg{Names: []string{"output", "o"}, HasArg: true, ArgInHelp: "<file>",
+			Group: "Output options", Desc: "Output file."},
+	}
+	cmd.ArgCfgs = []cliargs.ArgCfg{
+		cliargs.ArgCfg{Name: "src", Min: 1, Max: -1, Desc: "Source files."},
+	}
+
+	help := cliargs.NewHelp()
+	help.AddUsage(cmd)
+	help.AddText("Copies <src> files.\n.Hidden files are ignored.")
+	help.AddArgs(cmd.ArgCfgs)
+	help.AddOptGroups(cmd.OptCfgs)
+
+	assert.Equal(t, help.Markdown(), "```\n"+
+		"Usage: app [OPTIONS] <src>...\n"+
+		"```\n"+
+		"\n"+
+		"Copies \\<src\\> files.\\\n"+
+		".Hidden files are ignored.\n"+
+		"\n"+
+		"- `<src>...`: Source files.\n"+
+		"\n"+
+		"- `--verbose, -v`: Prints \\*all\\* details.\\\n"+
+		"  For debug.\n"+
+		"\n"+
+		"### Output options\n"+
+		"\n"+
+		"- `--output, -o <file>`: Output file.\n")
+}
+
+func TestHelp_Roff(t *testing.T) {
+	cmd := cliargs.NewCmdFromArgs("app", []string{})
+	cmd.OptCfgs = []cliargs.OptCfg{
+		cliargs.OptCfg{Names: []string{"verbose", "v"}, Desc: "Prints *all* details.\nFor debug."},
+		cliargs.OptCfg{Names: []string{"output", "o"}, HasArg: true, ArgInHelp: "<file>",
+			Group: "Output options", Desc: "Output file."},
+	}
+	cmd.ArgCfgs = []cliargs.ArgCfg{
+		cliargs.ArgCfg{Name: "src", Min: 1, Max: -1, Desc: "Source files."},
+	}
+
+	help := cliargs.NewHelp()
+	help.AddUsage(cmd)
+	help.AddText("Copies <src> files.\n.Hidden files are ignored.")
+	help.AddArgs(cmd.ArgCfgs)
+	help.AddOptGroups(cmd.OptCfgs)
+
+	assert.Equal(t, help.Roff("app", "1"), ".TH \"APP\" \"1\"\n"+
+		".PP\n"+
+		".nf\n"+
+		"Usage: app [OPTIONS] <src>...\n"+
+		".fi\n"+
+		".PP\n"+
+		"Copies <src> files.\n"+
+		".br\n"+
+		"\\&.Hidden files are ignored.\n"+
+		".TP\n"+
+		"\\fB<src>...\\fR\n"+
+		"Source files.\n"+
+		".TP\n"+
+		"\\fB\\-\\-verbose, \\-v\\fR\n"+
+		"Prints *all* details.\n"+
+		".br\n"+
+		"For debug.\n"+
+		".SS \"Output options\"\n"+
+		".TP\n"+
+		"\\fB\\-\\-output, \\-o <file>\\fR\n"+
+		"Output file.\n")
+}
+
+func TestHelp_HTML(t *testing.T) {
+	cmd := cliargs.NewCmdFromArgs("app", []string{})
+	cmd.OptCfgs = []cliargs.OptCfg{
+		cliargs.OptCfg{Names: []string{"verbose", "v"}, Desc: "Prints *all* details.\nFor debug."},
+		cliargs.OptCfg{Names: []string{"output", "o"}, HasArg: true, ArgInHelp: "<file>",
+			Group: "Output options", Desc: "Output file."},
+	}
+	cmd.ArgCfgs = []cliargs.ArgCfg{
+		cliargs.ArgCfg{Name: "src", Min: 1, Max: -1, Desc: "Source files."},
+	}
+
+	help := cliargs.NewHelp()
+	help.AddUsage(cmd)
+	help.AddText("Copies <src> files.\n.Hidden files are ignored.")
+	help.AddArgs(cmd.ArgCfgs)
+	help.AddOptGroups(cmd.OptCfgs)
+
+	assert.Equal(t, help.HTML(), "<pre>Usage: app [OPTIONS] &lt;src&gt;...</pre>\n"+
+		"<p>Copies &lt;src&gt; files.<br>\n"+
+		".Hidden files are ignored.</p>\n"+
+		"<dl>\n"+
+		"<dt><code>&lt;src&gt;...</code></dt>\n"+
+		"<dd>Source files.</dd>\n"+
+		"</dl>\n"+
+		"<dl>\n"+
+		"<dt><code>--verbose, -v</code></dt>\n"+
+		"<dd>Prints *all* details.<br>\n"+
+		"For debug.</dd>\n"+
+		"</dl>\n"+
+		"<h3>Output options</h3>\n"+
+		"<dl>\n"+
+		"<dt><code>--output, -o &lt;file&gt;</code></dt>\n"+
+		"<dd>Output file.</dd>\n"+
+		"</dl>\n")
+}
+
+func TestHelp_render_empty(t *testing.T) {
+	help := cliargs.NewHelp()
+
+	assert.Equal(t, help.Markdown(), "")
+	assert.Equal(t, help.Roff("app", "1"), ".TH \"APP\" \"1\"\n")
+	assert.Equal(t, help.HTML(), "")
+}
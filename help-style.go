@@ -0,0 +1,93 @@
+// Copyright (C) 2026 Takayuki Sato. All Rights Reserved.
+// This program is free software under MIT License.
+// See the file LICENSE in this distribution for more details.
+
+package cliargs
+
+import (
+	"io"
+	"os"
+	"strings"
+)
+
+// HelpStyle is the struct type which holds the styles of the parts of help texts.
+// Each style is the parameters of an ANSI SGR escape sequence, like "1" for bold, "2" for dim, or
+// "1;36" for bold and cyan.
+// An empty style means that the part is not styled.
+//
+// OptName is the style for option names and command argument names, OptArg is the style for the
+// placeholders of option arguments (ArgInHelp), and Title is the style for the titles of sections
+// added by Help#AddOptGroups.
+//
+// Styles are applied only when help texts are written to a terminal and the environment variable
+// NO_COLOR is not set.
+// If Always is true, styles are applied even when help texts are not written to a terminal, but
+// NO_COLOR still disables them.
+type HelpStyle struct {
+	OptName string
+	OptArg  string
+	Title   string
+	Always  bool
+}
+
+// DefaultHelpStyle is the HelpStyle which shows option names in bold, placeholders of option
+// arguments dimmed, and section titles in bold cyan.
+var DefaultHelpStyle = HelpStyle{OptName: "1", OptArg: "2", Title: "1;36"}
+
+// SetStyle is a method which sets the style of help texts written by Help#Print and Help#Fprint.
+//
+// Since styles are applied after lines are broken, escape sequences do not affect line widths
+// and the alignment of descriptions.
+// The lines returned by HelpIter and the texts rendered by Help#Markdown, Help#Roff and
+// Help#HTML are not styled.
+func (help *Help) SetStyle(style HelpStyle) {
+	help.style = &style
+}
+
+func (style HelpStyle) isEnabledFor(w io.Writer) bool {
+	if len(os.Getenv("NO_COLOR")) > 0 {
+		return false
+	}
+	if style.Always {
+		return true
+	}
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	fi, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return (fi.Mode() & os.ModeCharDevice) != 0
+}
+
+func (style HelpStyle) styleLine(line string, body blockBody, isFirstLine bool) string {
+	content := strings.TrimLeft(line, " ")
+	head := line[:len(line)-len(content)]
+
+	switch body.kind {
+	case titleBody:
+		return head + sgr(style.Title, content)
+	case entryBody:
+		if !isFirstLine || len(body.term) == 0 || !strings.HasPrefix(content, body.term) {
+			return line
+		}
+		rest := content[len(body.term):]
+		names := body.term
+		if len(body.termArg) > 0 && strings.HasSuffix(names, " "+body.termArg) {
+			names = names[:len(names)-len(body.termArg)-1]
+			return head + sgr(style.OptName, names) + " " + sgr(style.OptArg, body.termArg) + rest
+		}
+		return head + sgr(style.OptName, names) + rest
+	default:
+		return line
+	}
+}
+
+func sgr(params, text string) string {
+	if len(params) == 0 || len(text) == 0 {
+		return text
+	}
+	return "\x1b[" + params + "m" + text + "\x1b[0m"
+}
@@ -0,0 +1,108 @@
+package cliargs_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sttk/cliargs"
+)
+
+func TestHelp_SetStyle_always(t *testing.T) {
+	t.Setenv("NO_COLOR", "")
+
+	help := cliargs.NewHelp()
+	help.AddText("Options:")
+	help.AddOptGroups([]cliargs.OptCfg{
+		cliargs.OptCfg{Names: []string{"verbose", "v"}, Desc: "Verbose."},
+		cliargs.OptCfg{Names: []string{"output", "o"}, HasArg: true, ArgInHelp: "<file>",
+			Group: "Output", Desc: "Output file."},
+	})
+	help.AddArgsWithMargins([]cliargs.ArgCfg{
+		cliargs.ArgCfg{Name: "src", Min: 1, Desc: "Source."},
+	}, 2, 0)
+
+	style := cliargs.DefaultHelpStyle
+	style.Always = true
+	help.SetStyle(style)
+
+	var buf bytes.Buffer
+	err := help.Fprint(&buf, 80)
+	assert.Nil(t, err)
+	assert.Equal(t, buf.String(), "Options:\n"+
+		"  \x1b[1m--verbose, -v\x1b[0m        Verbose.\n"+
+		"\x1b[1;36mOutput\x1b[0m\n"+
+		"  \x1b[1m--output, -o\x1b[0m \x1b[2m<file>\x1b[0m  Output file.\n"+
+		"  \x1b[1m<src>\x1b[0m  Source.\n")
+}
+
+func TestHelp_SetStyle_disabledForNonTerminal(t *testing.T) {
+	t.Setenv("NO_COLOR", "")
+
+	help := cliargs.NewHelp()
+	help.AddText("Options:")
+	help.AddOptGroups([]cliargs.OptCfg{
+		cliargs.OptCfg{Names: []string{"verbose", "v"}, Desc: "Verbose."},
+		cliargs.OptCfg{Names: []string{"output", "o"}, HasArg: true, ArgInHelp: "<file>",
+			Group: "Output", Desc: "Output file."},
+	})
+	help.AddArgsWithMargins([]cliargs.ArgCfg{
+		cliargs.ArgCfg{Name: "src", Min: 1, Desc: "Source."},
+	}, 2, 0)
+
+	help.SetStyle(cliargs.DefaultHelpStyle)
+
+	var buf bytes.Buffer
+	err := help.Fprint(&buf, 80)
+	assert.Nil(t, err)
+	assert.Equal(t, buf.String(), "Options:\n"+
+		"  --verbose, -v        Verbose.\n"+
+		"Output\n"+
+		"  --output, -o <file>  Output file.\n"+
+		"  <src>  Source.\n")
+}
+
+func TestHelp_SetStyle_disabledByNO_COLOR(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+
+	help := cliargs.NewHelp()
+	help.AddText("Options:")
+	help.AddOptGroups([]cliargs.OptCfg{
+		cliargs.OptCfg{Names: []string{"verbose", "v"}, Desc: "Verbose."},
+		cliargs.OptCfg{Names: []string{"output", "o"}, HasArg: true, ArgInHelp: "<file>",
+			Group: "Output", Desc: "Output file."},
+	})
+	help.AddArgsWithMargins([]cliargs.ArgCfg{
+		cliargs.ArgCfg{Name: "src", Min: 1, Desc: "Source."},
+	}, 2, 0)
+
+	style := cliargs.DefaultHelpStyle
+	style.Always = true
+	help.SetStyle(style)
+
+	var buf bytes.Buffer
+	err := help.Fprint(&buf, 80)
+	assert.Nil(t, err)
+	assert.NotContains(t, buf.String(), "\x1b[")
+}
+
+func TestHelp_SetStyle_keepsWrapping(t *testing.T) {
+	t.Setenv("NO_COLOR", "")
+
+	help := cliargs.NewHelp()
+	help.AddOpts([]cliargs.OptCfg{
+		cliargs.OptCfg{Names: []string{"foo"}, Desc: "aaa bbb ccc"},
+		cliargs.OptCfg{Names: []string{"", "bar"}, HasArg: true, ArgInHelp: "<n>", Desc: "ddd"},
+	})
+	help.SetStyle(cliargs.HelpStyle{OptName: "1", Always: true})
+
+	var buf bytes.Buffer
+	err := help.Fprint(&buf, 20)
+	assert.Nil(t, err)
+	assert.Equal(t, buf.String(), ""+
+		"\x1b[1m--foo\x1b[0m          aaa\n"+
+		"               bbb\n"+
+		"               ccc\n"+
+		"    \x1b[1m--bar\x1b[0m <n>  ddd\n")
+}
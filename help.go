@@ -6,6 +6,9 @@ package cliargs
 
 import (
 	"fmt"
+	"io"
+	"os"
+	"strconv"
 	"strings"
 
 	"github.com/sttk/linebreak"
@@ -16,6 +19,7 @@ type Help struct {
 	marginLeft  int
 	marginRight int
 	blocks      []block
+	style       *HelpStyle
 }
 
 type block struct {
@@ -28,8 +32,24 @@ type block struct {
 type blockBody struct {
 	firstIndent int
 	text        string
+	kind        bodyKind
+	term        string
+	termArg     string
+	desc        string
 }
 
+// bodyKind is the type which indicates what a blockBody represents, and is used by the renderers
+// other than HelpIter, like Help#Markdown.
+// The term and desc fields of a blockBody are set only if its kind is entryBody.
+type bodyKind int
+
+const (
+	textBody bodyKind = iota
+	entryBody
+	titleBody
+	usageBody
+)
+
 // NewHelp is a function to construct a Help instance.
 func NewHelp() Help {
 	var help Help
@@ -220,13 +240,182 @@ func (help *Help) AddOptsWithIndentAndMargins(
 	help.blocks = append(help.blocks, b)
 }
 
+// AddOptGroups is a method which adds OptCfg(s) to this Help instance, dividing them into
+// sections by their Group fields.
+// Each section starts with a title line which is the Group name, and its options are indented by
+// 2 spaces from the title.
+// The options of which Group is empty are shown first without a title, and the sections are
+// ordered by the first appearance of their Group names.
+// The descriptions of options in all sections are aligned to a shared column.
+func (help *Help) AddOptGroups(optCfgs []OptCfg) {
+	help.addOptGroups(optCfgs, 2, 0)
+}
+
+// AddOptGroupsWithMargins is a method which adds OptCfg(s) divided into sections by their Group
+// fields, with left and right margins of options to this Help instance.
+// The title lines of sections are not affected by these margins.
+func (help *Help) AddOptGroupsWithMargins(optCfgs []OptCfg, marginLeft, marginRight int) {
+	help.addOptGroups(optCfgs, marginLeft, marginRight)
+}
+
+func (help *Help) addOptGroups(optCfgs []OptCfg, marginLeft, marginRight int) {
+	indent := 0
+	createOptsHelp(optCfgs, &indent)
+
+	var groups []string
+	groupCfgs := make(map[string][]OptCfg)
+	for _, cfg := range optCfgs {
+		if _, exists := groupCfgs[cfg.Group]; !exists && len(cfg.Group) > 0 {
+			groups = append(groups, cfg.Group)
+		}
+		groupCfgs[cfg.Group] = append(groupCfgs[cfg.Group], cfg)
+	}
+
+	addOpts := func(cfgs []OptCfg) {
+		b := block{
+			marginLeft:  help.marginLeft + marginLeft,
+			marginRight: help.marginRight + marginRight,
+			indent:      indent,
+		}
+		b.bodies = createOptsHelpIn(cfgs, optCfgs, &b.indent)
+		help.blocks = append(help.blocks, b)
+	}
+
+	if cfgs, exists := groupCfgs[""]; exists {
+		addOpts(cfgs)
+	}
+	for _, group := range groups {
+		help.blocks = append(help.blocks, block{
+			marginLeft:  help.marginLeft,
+			marginRight: help.marginRight,
+			bodies:      []blockBody{blockBody{firstIndent: 0, text: group, kind: titleBody}},
+		})
+		addOpts(groupCfgs[group])
+	}
+}
+
+// AddArgs is a method which adds ArgCfg(s) to this Help instance.
+func (help *Help) AddArgs(argCfgs []ArgCfg) {
+	b := block{
+		marginLeft:  help.marginLeft,
+		marginRight: help.marginRight,
+	}
+	b.bodies = createArgsHelp(argCfgs, &b.indent)
+	help.blocks = append(help.blocks, b)
+}
+
+// AddArgsWithIndent is a method which adds ArgCfg(s) with indent size to this Help instance.
+func (help *Help) AddArgsWithIndent(argCfgs []ArgCfg, indent int) {
+	b := block{
+		marginLeft:  help.marginLeft,
+		marginRight: help.marginRight,
+	}
+	b.indent = indent
+	b.bodies = createArgsHelp(argCfgs, &b.indent)
+	help.blocks = append(help.blocks, b)
+}
+
+// AddArgsWithMargins is a method which adds ArgCfg(s) with left and right margins to this Help
+// instance.
+func (help *Help) AddArgsWithMargins(argCfgs []ArgCfg, marginLeft, marginRight int) {
+	b := block{
+		marginLeft:  help.marginLeft,
+		marginRight: help.marginRight,
+	}
+	b.marginLeft += marginLeft
+	b.marginRight += marginRight
+	b.bodies = createArgsHelp(argCfgs, &b.indent)
+	help.blocks = append(help.blocks, b)
+}
+
+// AddArgsWithIndentAndMargins is a method which adds ArgCfg(s) with indent size, left and right
+// margins to this Help instance.
+func (help *Help) AddArgsWithIndentAndMargins(
+	argCfgs []ArgCfg, indent, marginLeft, marginRight int,
+) {
+	b := block{
+		marginLeft:  help.marginLeft,
+		marginRight: help.marginRight,
+	}
+	b.indent = indent
+	b.marginLeft += marginLeft
+	b.marginRight += marginRight
+	b.bodies = createArgsHelp(argCfgs, &b.indent)
+	help.blocks = append(help.blocks, b)
+}
+
+// AddUsage is a method which adds a usage line synthesized from the name, the option
+// configurations, and the argument configurations of the specified Cmd instance to this Help
+// instance, like "Usage: app [OPTIONS] --file <path> <src>... <dest>".
+//
+// The options of which option configurations are Required are shown with their ArgInHelp, and the
+// required exclusive groups are shown like "(--json | --yaml)".
+// The other options are summarized into "[OPTIONS]".
+// The command arguments are shown in the format of ArgsUsage.
+// If the usage line is longer than the line width, the following lines are indented to the
+// position after the command name.
+func (help *Help) AddUsage(cmd Cmd) {
+	b := block{
+		marginLeft:  help.marginLeft,
+		marginRight: help.marginRight,
+	}
+	text := makeUsage(cmd)
+	b.indent = linebreak.TextWidth(usagePrefix + cmd.Name + " ")
+	b.bodies = []blockBody{
+		blockBody{firstIndent: 0, text: text, kind: usageBody},
+	}
+	help.blocks = append(help.blocks, b)
+}
+
+// AddUsageWithMargins is a method which adds a usage line synthesized from the specified Cmd
+// instance with left and right margins to this Help instance.
+func (help *Help) AddUsageWithMargins(cmd Cmd, marginLeft, marginRight int) {
+	b := block{
+		marginLeft:  help.marginLeft,
+		marginRight: help.marginRight,
+	}
+	b.marginLeft += marginLeft
+	b.marginRight += marginRight
+	text := makeUsage(cmd)
+	b.indent = linebreak.TextWidth(usagePrefix + cmd.Name + " ")
+	b.bodies = []blockBody{
+		blockBody{firstIndent: 0, text: text, kind: usageBody},
+	}
+	help.blocks = append(help.blocks, b)
+}
+
+// ArgsUsage is a function which makes the part of a usage line for command arguments from ArgCfg(s),
+// like "<file> [rest...]".
+// A command argument which is required is enclosed by angle brackets, and an optional one is
+// enclosed by square brackets.
+// If an ArgCfg can take multiple command arguments, "..." is added, like "<file>..." or
+// "[file...]".
+func ArgsUsage(argCfgs []ArgCfg) string {
+	titles := make([]string, 0, len(argCfgs))
+	for _, cfg := range argCfgs {
+		titles = append(titles, makeArgTitle(cfg))
+	}
+	return strings.Join(titles, " ")
+}
+
 // Iter is a method which creates a HelpIter instance.
+// The line width is determined in the same way as IterWithWidth with zero.
 func (help Help) Iter() HelpIter {
+	return help.IterWithWidth(0)
+}
+
+// IterWithWidth is a method which creates a HelpIter instance which breaks lines with the
+// specified line width.
+// If the line width is zero or negative, the value of the environment variable COLUMNS is used if
+// it is a positive integer, otherwise the column count of the current terminal is used.
+func (help Help) IterWithWidth(lineWidth int) HelpIter {
 	if len(help.blocks) == 0 {
 		return HelpIter{}
 	}
 
-	lineWidth := linebreak.TermCols()
+	if lineWidth <= 0 {
+		lineWidth = defaultLineWidth()
+	}
 
 	return HelpIter{
 		lineWidth: lineWidth,
@@ -237,18 +426,49 @@ func (help Help) Iter() HelpIter {
 
 // Print is a method which prints help texts to standard output.
 func (help Help) Print() {
-	iter := help.Iter()
+	help.Fprint(os.Stdout, 0)
+}
+
+// Fprint is a method which writes help texts to the specified writer, breaking lines with the
+// specified line width.
+// If the line width is zero or negative, it is determined in the same way as IterWithWidth.
+// If a style is set with SetStyle, the help texts are styled with it unless styling is disabled
+// for the writer.
+// This method returns the first error which occurred while writing.
+func (help Help) Fprint(w io.Writer, lineWidth int) error {
+	iter := help.IterWithWidth(lineWidth)
+	if help.style != nil && help.style.isEnabledFor(w) {
+		iter.style = help.style
+	}
 
 	for {
 		line, exists := iter.Next()
 		if !exists {
-			break
+			return nil
+		}
+		_, err := fmt.Fprintln(w, line)
+		if err != nil {
+			return err
 		}
-		fmt.Println(line)
 	}
 }
 
+func defaultLineWidth() int {
+	cols, err := strconv.Atoi(os.Getenv("COLUMNS"))
+	if err == nil && cols > 0 {
+		return cols
+	}
+	return linebreak.TermCols()
+}
+
 func createOptsHelp(optCfgs []OptCfg, indent *int) []blockBody {
+	return createOptsHelpIn(optCfgs, optCfgs, indent)
+}
+
+// createOptsHelpIn is the function that creates the help text bodies of optCfgs, which are a part
+// of allCfgs.
+// allCfgs are used to find the other options in the same exclusive groups.
+func createOptsHelpIn(optCfgs []OptCfg, allCfgs []OptCfg, indent *int) []blockBody {
 	bodies := make([]blockBody, 0, len(optCfgs))
 	const ANY_OPT string = "*"
 
@@ -279,15 +499,19 @@ func createOptsHelp(optCfgs []OptCfg, indent *int) []blockBody {
 
 			width := firstIndent + linebreak.TextWidth(text)
 
-			if len(cfg.Desc) > 0 {
+			term := text
+			desc := makeOptDesc(cfg, allCfgs)
+			if len(desc) > 0 {
 				if width+2 > *indent {
-					text += "\n" + strings.Repeat(" ", *indent) + cfg.Desc
+					text += "\n" + strings.Repeat(" ", *indent) + desc
 				} else {
-					text += strings.Repeat(" ", *indent-width) + cfg.Desc
+					text += strings.Repeat(" ", *indent-width) + desc
 				}
 			}
 
-			bodies = append(bodies, blockBody{firstIndent, text})
+			bodies = append(bodies, blockBody{
+				firstIndent: firstIndent, text: text, kind: entryBody,
+				term: term, termArg: cfg.ArgInHelp, desc: desc})
 		}
 	} else {
 		widths := make([]int, 0, len(bodies))
@@ -322,7 +546,9 @@ func createOptsHelp(optCfgs []OptCfg, indent *int) []blockBody {
 				maxIndent = width
 			}
 
-			bodies = append(bodies, blockBody{firstIndent, text})
+			bodies = append(bodies, blockBody{
+				firstIndent: firstIndent, text: text, kind: entryBody,
+				term: text, termArg: cfg.ArgInHelp})
 			widths = append(widths, width)
 		}
 
@@ -352,8 +578,10 @@ func createOptsHelp(optCfgs []OptCfg, indent *int) []blockBody {
 				continue
 			}
 
-			if len(cfg.Desc) > 0 {
-				bodies[i].text += strings.Repeat(" ", maxIndent-widths[i]) + cfg.Desc
+			desc := makeOptDesc(cfg, allCfgs)
+			if len(desc) > 0 {
+				bodies[i].text += strings.Repeat(" ", maxIndent-widths[i]) + desc
+				bodies[i].desc = desc
 			}
 
 			i += 1
@@ -363,6 +591,165 @@ func createOptsHelp(optCfgs []OptCfg, indent *int) []blockBody {
 	return bodies
 }
 
+const usagePrefix = "Usage: "
+
+func makeUsage(cmd Cmd) string {
+	items := []string{usagePrefix + cmd.Name}
+
+	hasOtherOpts := false
+	var requiredOpts []string
+	doneGroups := make(map[string]bool)
+
+	for _, cfg := range cmd.OptCfgs {
+		storeKey := storeKeyOf(cfg)
+		if len(storeKey) == 0 {
+			continue
+		}
+		if storeKey == anyOption {
+			hasOtherOpts = true
+			continue
+		}
+
+		if len(cfg.ExclusiveGroup) > 0 {
+			if doneGroups[cfg.ExclusiveGroup] {
+				continue
+			}
+			isRequired := false
+			var names []string
+			for _, other := range cmd.OptCfgs {
+				if other.ExclusiveGroup == cfg.ExclusiveGroup {
+					isRequired = isRequired || other.Required
+					names = append(names, makeUsageOpt(other))
+				}
+			}
+			if isRequired {
+				doneGroups[cfg.ExclusiveGroup] = true
+				requiredOpts = append(requiredOpts, "("+strings.Join(names, " | ")+")")
+				continue
+			}
+			hasOtherOpts = true
+			continue
+		}
+
+		if cfg.Required {
+			requiredOpts = append(requiredOpts, makeUsageOpt(cfg))
+		} else {
+			hasOtherOpts = true
+		}
+	}
+
+	if hasOtherOpts {
+		items = append(items, "[OPTIONS]")
+	}
+	items = append(items, requiredOpts...)
+
+	if len(cmd.ArgCfgs) > 0 {
+		items = append(items, ArgsUsage(cmd.ArgCfgs))
+	}
+
+	return strings.Join(items, " ")
+}
+
+func makeUsageOpt(cfg OptCfg) string {
+	opt := optNameWithHyphens(firstNameOf(cfg))
+	if cfg.HasArg && len(cfg.ArgInHelp) > 0 {
+		opt += " " + cfg.ArgInHelp
+	}
+	return opt
+}
+
+func createArgsHelp(argCfgs []ArgCfg, indent *int) []blockBody {
+	bodies := make([]blockBody, 0, len(argCfgs))
+
+	if *indent <= 0 {
+		maxWidth := 0
+		for _, cfg := range argCfgs {
+			width := linebreak.TextWidth(makeArgTitle(cfg))
+			if maxWidth < width {
+				maxWidth = width
+			}
+		}
+		*indent = maxWidth + 2
+	}
+
+	for _, cfg := range argCfgs {
+		term := makeArgTitle(cfg)
+		text := term
+		width := linebreak.TextWidth(text)
+
+		if len(cfg.Desc) > 0 {
+			if width+2 > *indent {
+				text += "\n" + strings.Repeat(" ", *indent) + cfg.Desc
+			} else {
+				text += strings.Repeat(" ", *indent-width) + cfg.Desc
+			}
+		}
+
+		bodies = append(bodies, blockBody{
+			firstIndent: 0, text: text, kind: entryBody, term: term, desc: cfg.Desc})
+	}
+
+	return bodies
+}
+
+func makeArgTitle(cfg ArgCfg) string {
+	min, max := cfg.countRange()
+	ellipsis := ""
+	if max < 0 || max > 1 {
+		ellipsis = "..."
+	}
+	if min > 0 {
+		return "<" + cfg.Name + ">" + ellipsis
+	}
+	return "[" + cfg.Name + ellipsis + "]"
+}
+
+func makeOptDesc(cfg OptCfg, optCfgs []OptCfg) string {
+	var notes []string
+
+	if len(cfg.ExclusiveGroup) > 0 {
+		isRequired := false
+		var others []string
+		for _, other := range optCfgs {
+			if other.ExclusiveGroup != cfg.ExclusiveGroup {
+				continue
+			}
+			isRequired = isRequired || other.Required
+			if storeKeyOf(other) != storeKeyOf(cfg) {
+				others = append(others, optNameWithHyphens(firstNameOf(other)))
+			}
+		}
+		note := "exclusive with " + strings.Join(others, ", ")
+		if isRequired {
+			note = "required, " + note
+		}
+		notes = append(notes, "("+note+")")
+	} else if cfg.Required {
+		notes = append(notes, "(required)")
+	}
+
+	if len(cfg.Requires) > 0 {
+		names := make([]string, len(cfg.Requires))
+		for i, nm := range cfg.Requires {
+			names[i] = optNameWithHyphens(nm)
+		}
+		notes = append(notes, "(requires "+strings.Join(names, ", ")+")")
+	}
+
+	if len(cfg.DefaultInHelp) > 0 {
+		notes = append(notes, "(default: "+cfg.DefaultInHelp+")")
+	}
+
+	desc := cfg.Desc
+	for _, note := range notes {
+		if len(desc) > 0 {
+			desc += " "
+		}
+		desc += note
+	}
+	return desc
+}
+
 func makeOptTitle(cfg OptCfg) (int, string) {
 	headSpaces := 0
 	lastSpaces := 0
@@ -432,11 +819,13 @@ type HelpIter struct {
 	lineWidth int
 	blocks    []block
 	blockIter blockIter
+	style     *HelpStyle
 }
 
 type blockIter struct {
 	bodies   []blockBody
 	index    int
+	lineNo   int
 	indent   string
 	margin   string
 	lineIter linebreak.LineIter
@@ -449,6 +838,10 @@ func (iter *HelpIter) Next() (string, bool) {
 	for {
 		line, exists := iter.blockIter.next()
 		if exists {
+			if iter.style != nil {
+				body := iter.blockIter.bodies[iter.blockIter.index]
+				line = iter.style.styleLine(line, body, iter.blockIter.lineNo == 1)
+			}
 			return line, true
 		}
 		if len(iter.blocks) <= 1 {
@@ -490,9 +883,11 @@ func (iter *blockIter) next() (string, bool) {
 			line = iter.margin + line
 		}
 		if exists {
+			iter.lineNo++
 			return line, true
 		}
 		iter.index++
+		iter.lineNo = 0
 		if iter.index >= len(iter.bodies) {
 			return "", false
 		}
@@ -1,6 +1,8 @@
 package cliargs_test
 
 import (
+	"bytes"
+	goerrors "errors"
 	"strings"
 	"testing"
 
@@ -965,3 +967,328 @@ func TestHelp_AddOpts_ifNamesContainsEmptyStrings(t *testing.T) {
 	assert.False(t, exists)
 	assert.Equal(t, line, "")
 }
+
+func TestHelp_AddOpts_required(t *testing.T) {
+	help := cliargs.NewHelp()
+	help.AddOpts([]cliargs.OptCfg{
+		cliargs.OptCfg{Names: []string{"foo", "f"}, HasArg: true, Required: true, Desc: "Foo."},
+		cliargs.OptCfg{Names: []string{"bar"}, Required: true},
+		cliargs.OptCfg{Names: []string{"baz"}, Desc: "Baz."},
+	})
+
+	iter := help.Iter()
+
+	line, exists := iter.Next()
+	assert.Equal(t, line, "--foo, -f  Foo. (required)")
+	assert.True(t, exists)
+
+	line, exists = iter.Next()
+	assert.Equal(t, line, "--bar      (required)")
+	assert.True(t, exists)
+
+	line, exists = iter.Next()
+	assert.Equal(t, line, "--baz      Baz.")
+	assert.True(t, exists)
+
+	line, exists = iter.Next()
+	assert.Equal(t, line, "")
+	assert.False(t, exists)
+}
+
+func TestHelp_AddOpts_exclusiveGroupAndRequires(t *testing.T) {
+	help := cliargs.NewHelp()
+	help.AddOpts([]cliargs.OptCfg{
+		cliargs.OptCfg{Names: []string{"json"}, ExclusiveGroup: "format", Desc: "JSON."},
+		cliargs.OptCfg{Names: []string{"yaml", "y"}, ExclusiveGroup: "format"},
+		cliargs.OptCfg{Names: []string{"file"}, ExclusiveGroup: "in", Required: true},
+		cliargs.OptCfg{Names: []string{"stdin"}, ExclusiveGroup: "in"},
+		cliargs.OptCfg{Names: []string{"user"}, Requires: []string{"password", "p"}, Desc: "User."},
+	})
+
+	iter := help.Iter()
+
+	line, _ := iter.Next()
+	assert.Equal(t, line, "--json      JSON. (exclusive with --yaml)")
+	line, _ = iter.Next()
+	assert.Equal(t, line, "--yaml, -y  (exclusive with --json)")
+	line, _ = iter.Next()
+	assert.Equal(t, line, "--file      (required, exclusive with --stdin)")
+	line, _ = iter.Next()
+	assert.Equal(t, line, "--stdin     (required, exclusive with --file)")
+	line, _ = iter.Next()
+	assert.Equal(t, line, "--user      User. (requires --password, -p)")
+
+	_, exists := iter.Next()
+	assert.False(t, exists)
+}
+
+func TestHelp_AddArgs(t *testing.T) {
+	help := cliargs.NewHelp()
+	help.AddArgs([]cliargs.ArgCfg{
+		cliargs.ArgCfg{Name: "src", Min: 1, Max: -1, Desc: "Source files."},
+		cliargs.ArgCfg{Name: "dest", Min: 1, Desc: "Destination."},
+		cliargs.ArgCfg{Name: "mode"},
+		cliargs.ArgCfg{Name: "rest", Max: -1, Desc: "Others."},
+	})
+
+	iter := help.Iter()
+
+	line, _ := iter.Next()
+	assert.Equal(t, line, "<src>...   Source files.")
+	line, _ = iter.Next()
+	assert.Equal(t, line, "<dest>     Destination.")
+	line, _ = iter.Next()
+	assert.Equal(t, line, "[mode]")
+	line, _ = iter.Next()
+	assert.Equal(t, line, "[rest...]  Others.")
+
+	_, exists := iter.Next()
+	assert.False(t, exists)
+}
+
+func TestHelp_AddArgsWithIndentAndMargins(t *testing.T) {
+	help := cliargs.NewHelp()
+	help.AddArgsWithIndentAndMargins([]cliargs.ArgCfg{
+		cliargs.ArgCfg{Name: "file", Min: 1, Desc: "A file."},
+		cliargs.ArgCfg{Name: "long-argument-name", Desc: "Long."},
+	}, 8, 2, 0)
+
+	iter := help.Iter()
+
+	line, _ := iter.Next()
+	assert.Equal(t, line, "  <file>  A file.")
+	line, _ = iter.Next()
+	assert.Equal(t, line, "  [long-argument-name]")
+	line, _ = iter.Next()
+	assert.Equal(t, line, "          Long.")
+
+	_, exists := iter.Next()
+	assert.False(t, exists)
+}
+
+func TestArgsUsage(t *testing.T) {
+	usage := cliargs.ArgsUsage([]cliargs.ArgCfg{
+		cliargs.ArgCfg{Name: "file", Min: 1},
+		cliargs.ArgCfg{Name: "point", Min: 2},
+		cliargs.ArgCfg{Name: "mode"},
+		cliargs.ArgCfg{Name: "rest", Max: -1},
+	})
+	assert.Equal(t, usage, "<file> <point>... [mode] [rest...]")
+
+	assert.Equal(t, cliargs.ArgsUsage(nil), "")
+}
+
+func TestHelp_AddUsage(t *testing.T) {
+	cmd := cliargs.NewCmdFromArgs("app", []string{})
+	cmd.OptCfgs = []cliargs.OptCfg{
+		cliargs.OptCfg{Names: []string{"verbose", "v"}},
+		cliargs.OptCfg{Names: []string{"file", "f"}, HasArg: true, Required: true, ArgInHelp: "<path>"},
+		cliargs.OptCfg{Names: []string{"json"}, ExclusiveGroup: "format", Required: true},
+		cliargs.OptCfg{Names: []string{"yaml"}, ExclusiveGroup: "format"},
+	}
+	cmd.ArgCfgs = []cliargs.ArgCfg{
+		cliargs.ArgCfg{Name: "src", Min: 1, Max: -1},
+		cliargs.ArgCfg{Name: "dest", Min: 1},
+	}
+
+	help := cliargs.NewHelp()
+	help.AddUsage(cmd)
+
+	iter := help.Iter()
+
+	line, _ := iter.Next()
+	assert.Equal(t, line,
+		"Usage: app [OPTIONS] --file <path> (--json | --yaml) <src>... <dest>")
+
+	_, exists := iter.Next()
+	assert.False(t, exists)
+}
+
+func TestHelp_AddUsage_noOptsAndNoArgs(t *testing.T) {
+	help := cliargs.NewHelp()
+	help.AddUsage(cliargs.NewCmdFromArgs("app", []string{}))
+
+	iter := help.Iter()
+
+	line, _ := iter.Next()
+	assert.Equal(t, line, "Usage: app")
+
+	_, exists := iter.Next()
+	assert.False(t, exists)
+}
+
+func TestHelp_AddUsage_onlyOptionalOpts(t *testing.T) {
+	cmd := cliargs.NewCmdFromArgs("app", []string{})
+	cmd.OptCfgs = []cliargs.OptCfg{
+		cliargs.OptCfg{Names: []string{"json"}, ExclusiveGroup: "format"},
+		cliargs.OptCfg{Names: []string{"yaml"}, ExclusiveGroup: "format"},
+		cliargs.OptCfg{StoreKey: "*"},
+	}
+
+	help := cliargs.NewHelp()
+	help.AddUsage(cmd)
+
+	iter := help.Iter()
+
+	line, _ := iter.Next()
+	assert.Equal(t, line, "Usage: app [OPTIONS]")
+
+	_, exists := iter.Next()
+	assert.False(t, exists)
+}
+
+func TestHelp_AddUsageWithMargins_wrapsLine(t *testing.T) {
+	termCols := linebreak.TermCols()
+
+	cmd := cliargs.NewCmdFromArgs("app", []string{})
+	cmd.OptCfgs = []cliargs.OptCfg{
+		cliargs.OptCfg{Names: []string{"verbose"}},
+		cliargs.OptCfg{Names: []string{"file"}, HasArg: true, Required: true, ArgInHelp: "<path>"},
+	}
+	cmd.ArgCfgs = []cliargs.ArgCfg{
+		cliargs.ArgCfg{Name: "source", Min: 1, Max: -1},
+		cliargs.ArgCfg{Name: "destination", Min: 1},
+	}
+
+	help := cliargs.NewHelpWithMargins(1, 0)
+	help.AddUsageWithMargins(cmd, 1, termCols-32)
+
+	iter := help.Iter()
+
+	line, _ := iter.Next()
+	assert.Equal(t, line, "  Usage: app [OPTIONS] --file")
+	line, _ = iter.Next()
+	assert.Equal(t, line, "             <path> <source>...")
+	line, _ = iter.Next()
+	assert.Equal(t, line, "             <destination>")
+
+	_, exists := iter.Next()
+	assert.False(t, exists)
+}
+
+func TestHelp_AddOptGroups(t *testing.T) {
+	help := cliargs.NewHelp()
+	help.AddOptGroups([]cliargs.OptCfg{
+		cliargs.OptCfg{Names: []string{"help", "h"}, Desc: "Shows help."},
+		cliargs.OptCfg{Names: []string{"host"}, HasArg: true, ArgInHelp: "<addr>",
+			Group: "Network options", Desc: "Host address."},
+		cliargs.OptCfg{Names: []string{"output", "o"}, HasArg: true, ArgInHelp: "<file>",
+			Group: "Output options", Desc: "Output file."},
+		cliargs.OptCfg{Names: []string{"timeout", "t"}, HasArg: true, ArgInHelp: "<seconds>",
+			Group: "Network options", Desc: "Timeout."},
+		cliargs.OptCfg{Names: []string{"json"}, ExclusiveGroup: "format",
+			Group: "Output options"},
+		cliargs.OptCfg{Names: []string{"yaml"}, ExclusiveGroup: "format"},
+	})
+
+	iter := help.Iter()
+
+	line, _ := iter.Next()
+	assert.Equal(t, line, "  --help, -h               Shows help.")
+	line, _ = iter.Next()
+	assert.Equal(t, line, "  --yaml                   (exclusive with --json)")
+	line, _ = iter.Next()
+	assert.Equal(t, line, "Network options")
+	line, _ = iter.Next()
+	assert.Equal(t, line, "  --host <addr>            Host address.")
+	line, _ = iter.Next()
+	assert.Equal(t, line, "  --timeout, -t <seconds>  Timeout.")
+	line, _ = iter.Next()
+	assert.Equal(t, line, "Output options")
+	line, _ = iter.Next()
+	assert.Equal(t, line, "  --output, -o <file>      Output file.")
+	line, _ = iter.Next()
+	assert.Equal(t, line, "  --json                   (exclusive with --yaml)")
+
+	_, exists := iter.Next()
+	assert.False(t, exists)
+}
+
+func TestHelp_AddOptGroupsWithMargins(t *testing.T) {
+	help := cliargs.NewHelpWithMargins(1, 0)
+	help.AddOptGroupsWithMargins([]cliargs.OptCfg{
+		cliargs.OptCfg{Names: []string{"verbose"}, Group: "General", Desc: "Verbose."},
+		cliargs.OptCfg{Names: []string{"q"}, Group: "Other", Desc: "Quiet."},
+	}, 4, 0)
+
+	iter := help.Iter()
+
+	line, _ := iter.Next()
+	assert.Equal(t, line, " General")
+	line, _ = iter.Next()
+	assert.Equal(t, line, "     --verbose  Verbose.")
+	line, _ = iter.Next()
+	assert.Equal(t, line, " Other")
+	line, _ = iter.Next()
+	assert.Equal(t, line, "     -q         Quiet.")
+
+	_, exists := iter.Next()
+	assert.False(t, exists)
+}
+
+func TestHelp_IterWithWidth(t *testing.T) {
+	help := cliargs.NewHelp()
+	help.AddText("aaa bbb ccc ddd eee")
+
+	iter := help.IterWithWidth(8)
+
+	line, _ := iter.Next()
+	assert.Equal(t, line, "aaa bbb")
+	line, _ = iter.Next()
+	assert.Equal(t, line, "ccc ddd")
+	line, _ = iter.Next()
+	assert.Equal(t, line, "eee")
+
+	_, exists := iter.Next()
+	assert.False(t, exists)
+}
+
+func TestHelp_Fprint(t *testing.T) {
+	help := cliargs.NewHelp()
+	help.AddText("aaa bbb ccc ddd eee")
+	help.AddOpts([]cliargs.OptCfg{
+		cliargs.OptCfg{Names: []string{"foo"}, Desc: "Foo description."},
+	})
+
+	var buf bytes.Buffer
+	err := help.Fprint(&buf, 20)
+	assert.Nil(t, err)
+	assert.Equal(t, buf.String(), "aaa bbb ccc ddd eee\n"+
+		"--foo  Foo\n"+
+		"       description.\n")
+}
+
+func TestHelp_Fprint_zeroWidthUsesCOLUMNS(t *testing.T) {
+	t.Setenv("COLUMNS", "8")
+
+	help := cliargs.NewHelp()
+	help.AddText("aaa bbb ccc")
+
+	var buf bytes.Buffer
+	err := help.Fprint(&buf, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, buf.String(), "aaa bbb\nccc\n")
+
+	t.Setenv("COLUMNS", "xx")
+
+	buf.Reset()
+	err = help.Fprint(&buf, 0)
+	assert.Nil(t, err)
+	if linebreak.TermCols() >= 11 {
+		assert.Equal(t, buf.String(), "aaa bbb ccc\n")
+	}
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write(p []byte) (int, error) {
+	return 0, goerrors.New("write error")
+}
+
+func TestHelp_Fprint_writeError(t *testing.T) {
+	help := cliargs.NewHelp()
+	help.AddText("aaa")
+
+	err := help.Fprint(failingWriter{}, 80)
+	assert.Equal(t, err.Error(), "write error")
+}
@@ -6,7 +6,9 @@ package cliargs
 
 // OptCfg is the struct that represents an option configuration.
 // An option configuration consists of fields: StoreKey, Names, HasArg,
-// IsArray, Defaults, Desc, and ArgInHelp.
+// IsArray, IsMap, RejectsDupKey, Defaults, EnvVars, IsConfigFile, Required,
+// ExclusiveGroup, Requires, IsHelp, IsVersion, Validator, Completer, Desc,
+// ArgInHelp, DefaultInHelp, and Group.
 //
 // The StoreKey field is the key to store a option value(s) in the option map.
 // If this key is not specified or empty, the first element of Names field is
@@ -24,9 +26,68 @@ package cliargs
 // If both HasArg and IsArray are false, the option can take no option
 // argument.
 //
+// IsMap is the flag which allows the option to take option arguments of the
+// form of key=value, like --label env=prod, multiple times.
+// This flag is effective only when HasArg is true.
+// An option argument without "=" causes OptionArgIsInvalid error of which
+// Cause is OptArgIsNotKeyValue error, and Validator validates only the value
+// part of an option argument.
+// If a key is given multiple times, the last value wins, but if RejectsDupKey
+// is true, the parsing fails with MapKeyIsDuplicated error instead.
+// The option arguments can be retrieved as a map with Cmd#OptMap.
+//
 // Defaults is the field to specified the default value for when the option is
 // not given in command line arguments.
 //
+// EnvVars is the field to specify the names of environment variables which are
+// used as the option argument(s) for when the option is not given in command
+// line arguments.
+// These environment variables are looked up in order before Defaults is used,
+// and the first one which is set and not empty is used.
+// If IsArray or IsMap is true, the value of the environment variable is split
+// with commas.
+// If HasArg is false, the value of the environment variable is parsed as a
+// boolean, and the option is regarded as given only if the value is true.
+//
+// IsConfigFile is the flag which indicates that the option argument(s) of
+// this option are paths of configuration files.
+// This flag is effective only when HasArg is true.
+// The values in the configuration files are used as the option arguments of
+// the options of which StoreKey matches the keys in the files, when the options
+// are given neither in command line arguments nor in environment variables.
+// The format of a configuration file is determined by its extension: .json is
+// JSON, .yaml and .yml are a subset of YAML, and .ini, .toml, .conf and .cfg
+// are TOML-like INI.
+// Keys in nested objects, sections, or mappings are joined with dots.
+// For an option of which IsMap is true, the keys following its StoreKey and a
+// dot are used as the keys of the map, like env of Label.env.
+// If the path is given by Defaults and the file does not exist, the file is
+// ignored.
+//
+// Required is the flag which indicates that the option must be given.
+// If the option is given neither in command line arguments, environment
+// variables, configuration files, nor Defaults, the parsing fails with
+// MissingRequiredOption error.
+//
+// ExclusiveGroup is the field to specify the name of a group of options which
+// are mutually exclusive.
+// At most one of the options in the same group can be given.
+// If Required is true for any option in the group, exactly one of the options
+// in the group must be given, instead of every such option.
+//
+// Requires is the field to specify the names or the store keys of other
+// options which must be present when this option is given.
+//
+// IsHelp and IsVersion are the flags which indicate that the option requests
+// a help text or a version.
+// If such an option is given in command line arguments, the parsing returns
+// HelpIsRequested or VersionIsRequested error instead of any other errors.
+//
+// Completer is the field for a function which returns the candidates of the
+// option argument which start with the prefix given as its argument.
+// This function is called at runtime by a shell completion script through the
+// reserved command argument: __complete.
+//
 // OnParsed is the field for a function which is called when the option has
 // been parsed.
 //
@@ -34,14 +95,33 @@ package cliargs
 //
 // ArgInHelp is a display of the argument of this option in a help text.
 // The example of the display is like: -o, --option <value>.
+//
+// DefaultInHelp is a display of the default value of this option in a help
+// text, which is shown after the description like: (default: info).
+// This field is only for a help text, and is not used as an option argument.
+//
+// Group is the field to specify the name of the section in which this option
+// is shown in a help text by Help#AddOptGroups, like "Network options".
 type OptCfg struct {
-	StoreKey  string
-	Names     []string
-	HasArg    bool
-	IsArray   bool
-	Defaults  []string
-	Validator *func(string, string, string) error
-	Desc      string
-	ArgInHelp string
-	onParsed  *func([]string) error
+	StoreKey       string
+	Names          []string
+	HasArg         bool
+	IsArray        bool
+	IsMap          bool
+	RejectsDupKey  bool
+	Defaults       []string
+	EnvVars        []string
+	IsConfigFile   bool
+	Required       bool
+	ExclusiveGroup string
+	Requires       []string
+	IsHelp         bool
+	IsVersion      bool
+	Validator      *func(string, string, string) error
+	Completer      *func(prefix string) []string
+	Desc           string
+	ArgInHelp      string
+	DefaultInHelp  string
+	Group          string
+	onParsed       *func([]string) error
 }
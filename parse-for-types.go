@@ -0,0 +1,122 @@
+// Copyright (C) 2026 Takayuki Sato. All Rights Reserved.
+// This program is free software under MIT License.
+// See the file LICENSE in this distribution for more details.
+
+package cliargs
+
+import (
+	"fmt"
+	"net"
+	"net/netip"
+	"net/url"
+	"os"
+	"reflect"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/sttk/cliargs/errors"
+)
+
+// typeParsers is the map of the functions which convert an option argument to a value of a type
+// which cannot be determined only by its kind, like time.Duration of which kind is int64.
+// The second argument of each function is the value of the struct tag optlayout of the field.
+var typeParsers = map[reflect.Type]func(string, string) (any, error){
+	reflect.TypeOf(time.Duration(0)): func(s, _ string) (any, error) {
+		return time.ParseDuration(s)
+	},
+	reflect.TypeOf(time.Time{}): func(s, layout string) (any, error) {
+		if len(layout) == 0 {
+			layout = time.RFC3339
+		}
+		return time.Parse(layout, s)
+	},
+	reflect.TypeOf(net.IP{}): func(s, _ string) (any, error) {
+		ip := net.ParseIP(s)
+		if ip == nil {
+			return nil, fmt.Errorf("invalid IP address: %s", s)
+		}
+		return ip, nil
+	},
+	reflect.TypeOf(net.IPNet{}): func(s, _ string) (any, error) {
+		_, ipNet, e := net.ParseCIDR(s)
+		if e != nil {
+			return nil, e
+		}
+		return *ipNet, nil
+	},
+	reflect.TypeOf(netip.Addr{}): func(s, _ string) (any, error) {
+		return netip.ParseAddr(s)
+	},
+	reflect.TypeOf(netip.Prefix{}): func(s, _ string) (any, error) {
+		return netip.ParsePrefix(s)
+	},
+	reflect.TypeOf((*url.URL)(nil)): func(s, _ string) (any, error) {
+		return url.Parse(s)
+	},
+	reflect.TypeOf((*regexp.Regexp)(nil)): func(s, _ string) (any, error) {
+		return regexp.Compile(s)
+	},
+	reflect.TypeOf(os.FileMode(0)): func(s, _ string) (any, error) {
+		n, e := strconv.ParseUint(s, 8, 32)
+		if e != nil {
+			return nil, e
+		}
+		return os.FileMode(n), nil
+	},
+}
+
+func isParsableType(t reflect.Type) bool {
+	_, exists := typeParsers[t]
+	return exists
+}
+
+func newTypeSetter(
+	optName string, fldName string, fld reflect.Value, layout string,
+) (func([]string) error, error) {
+	t := fld.Type()
+	parse := typeParsers[t]
+	fn := func(s []string) error {
+		if len(s) == 0 {
+			return nil
+		}
+		v, e := parse(s[0], layout)
+		if e != nil {
+			return errors.OptionArgIsInvalid{
+				Option: optName, StoreKey: fldName, OptArg: s[0], TypeKind: t.Kind(), Cause: e}
+		}
+		fld.Set(reflect.ValueOf(v))
+		return nil
+	}
+	return fn, nil
+}
+
+func newTypeArraySetter(
+	optName string, fldName string, fld reflect.Value, layout string,
+) (func([]string) error, error) {
+	t := fld.Type().Elem()
+	parse := typeParsers[t]
+	fn := func(s []string) error {
+		if s == nil {
+			return nil
+		}
+		emp := reflect.MakeSlice(fld.Type(), 0, 0)
+		n := len(s)
+		if n == 0 {
+			fld.Set(emp)
+			return nil
+		}
+		a := make([]reflect.Value, n)
+		for i := 0; i < n; i++ {
+			v, e := parse(s[i], layout)
+			if e != nil {
+				return errors.OptionArgIsInvalid{
+					Option: optName, StoreKey: fldName, OptArg: s[i], TypeKind: t.Kind(), Cause: e}
+			}
+			a[i] = reflect.ValueOf(v)
+		}
+		fld.Set(reflect.Append(emp, a...))
+		return nil
+	}
+	return fn, nil
+}
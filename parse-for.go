@@ -30,6 +30,25 @@ import (
 // If the type is an array, the option can takes multiple option arguments,
 // therefore it can appear multiple times in command line arguments.
 //
+// In addition to the above types, time.Duration, time.Time, net.IP, net.IPNet, netip.Addr,
+// netip.Prefix, *url.URL, *regexp.Regexp, os.FileMode, and the arrays of them are supported.
+// A time.Time option argument is parsed with the layout specified with a struct tag like
+// `optlayout:"2006-01-02"`, or time.RFC3339 if not specified.
+// An os.FileMode option argument is parsed as an octal number, like 0644.
+// Moreover, a type of which pointer implements Value or encoding.TextUnmarshaler, and the arrays
+// of it are supported, and option arguments are converted with its Set or UnmarshalText method.
+// Such a type takes single option argument even if it is a slice.
+//
+// A field of a map type of which key type is string, like map[string]string or map[string]int,
+// takes option arguments of the form of key=value multiple times, like --label env=prod.
+// The values are converted to the element type of the map, and the last value wins if a key is
+// given multiple times, but a struct tag `optnodupkey:"true"` makes such duplication an error.
+// The default values of a map option are written like an array, like `optcfg:"label=[a=1,b=2]"`.
+//
+// A field of a pointer type to the above types, like *int or *time.Duration, is left nil if the
+// option is given neither in command line arguments, environment variables, configuration files,
+// nor default values, so that it can be distinguished from the zero value.
+//
 // A struct tag can be specified an option names and default value(s).
 // It has a special format like `opt:foo-bar,f=123`.
 // This opt: is the struct tag key for the option configuration.
@@ -52,6 +71,33 @@ import (
 // separator before the open square bracket, like :[elem1:elem2:elem3].
 // It's useful when some array elements include commas.
 //
+// The names of environment variables which are used when the option is not given in command line
+// arguments can be specified with a struct tag like `optenv:"FOO_BAR,FOO"`.
+// And a struct tag `optconfig:"true"` indicates that the option argument is a path of a
+// configuration file, of which values are set to the fields that have the same names as the keys.
+// A struct tag `optreq:"true"` indicates that the option is required.
+// A struct tag `optexcl:"group"` specifies the name of the group of mutually exclusive options, and
+// a struct tag `optrequires:"foo,bar"` specifies the names of the options which are required when
+// the option is given.
+// A struct tag `optaction:"help"` or `optaction:"version"` indicates that the option requests a
+// help text or a version.
+// A struct tag `optgroup:"Network options"` specifies the name of the section in which the option
+// is shown in a help text.
+//
+// The fields of an embedded struct are handled as the options of the option store, and the fields
+// of a nested struct field are handled as the options of which store keys are joined with the
+// field name, like DB.Host.
+// A struct tag `optprefix:"db-"` of such a field prepends the prefix to the option names of its
+// fields, like --db-host.
+// If a nested struct field has no optprefix, its field name and a hyphen are used as the prefix,
+// like --DB-host.
+//
+// A field with a struct tag `optpos:"0"` or `optpos:"rest"` is not an option but receives command
+// arguments, and the argument configurations made from such fields by MakeArgCfgsFor are set to
+// the field `ArgCfgs` of this Cmd instance.
+// These argument configurations are replaced or cleared by the next call of this method and are
+// cleared by ResetArgs.
+//
 // NOTE: A default value of an empty string array option in a struct tag is [],
 // like `opt:"name=[]"`, it doesn't represent an array which contains only one
 // empty string but an empty array.
@@ -61,7 +107,19 @@ func (cmd *Cmd) ParseFor(optStore any) error {
 	cfgs, err := MakeOptCfgsFor(optStore)
 	if err != nil {
 		cmd.OptCfgs = cfgs
-		return err
+		return cmd.toErr([]error{err})
+	}
+	argCfgs, err := MakeArgCfgsFor(optStore)
+	if err != nil {
+		cmd.OptCfgs = cfgs
+		return cmd.toErr([]error{err})
+	}
+	if len(argCfgs) > 0 {
+		cmd.ArgCfgs = argCfgs
+		cmd.isArgCfgsOfStore = true
+	} else if cmd.isArgCfgsOfStore {
+		cmd.ArgCfgs = nil
+		cmd.isArgCfgsOfStore = false
 	}
 	return cmd.ParseWith(cfgs)
 }
@@ -78,13 +136,32 @@ func (cmd *Cmd) ParseUntilSubCmdFor(optStore any) (Cmd, error) {
 	cfgs, err := MakeOptCfgsFor(optStore)
 	if err != nil {
 		cmd.OptCfgs = cfgs
-		return Cmd{}, err
+		return Cmd{}, cmd.toErr([]error{err})
 	}
 	return cmd.ParseUntilSubCmdWith(cfgs)
 }
 
 // MakeOptCfgsFor is a function to make a OptCfg array from fields of the option store which is
 // the argument of this function.
+// The fields which have the struct tag optpos are skipped.
+//
+// The fields of an embedded struct are handled as if they are the fields of the embedding struct,
+// so that a set of options can be shared among multiple option stores.
+// The fields of a nested struct field are handled as options too, and their store keys are joined
+// with the name of the nested struct field and a dot, like DB.Host.
+// The option names of the fields in an embedded or nested struct field can be prefixed with the
+// struct tag optprefix of that field, like `optprefix:"db-"`, which makes --db-host from the field
+// Host of which option name is host.
+// If a nested struct field has no optprefix, the prefix is its field name and a hyphen, like
+// --DB-host, and `optprefix:""` means no prefix.
+// If a field in an embedded or nested struct field has no option name, the prefix is followed by
+// the field name, like --db-Host, instead of the store key.
+// The names in the struct tag optrequires which are the option names of the fields in the same
+// struct are prefixed too, the names which are the field names in the same struct are converted to
+// their store keys, like DB.Password, and the other names are left as they are, so that they can
+// refer to the options outside the struct.
+// The group name in the struct tag optexcl is prefixed too, so that the options in a struct used
+// for multiple fields do not share a group.
 func MakeOptCfgsFor(options any) ([]OptCfg, error) {
 	v := reflect.ValueOf(options)
 	if v.Kind() != reflect.Ptr {
@@ -92,31 +169,127 @@ func MakeOptCfgsFor(options any) ([]OptCfg, error) {
 	}
 	v = v.Elem()
 
+	optCfgs := make([]OptCfg, 0, v.NumField())
+	return appendOptCfgsFor(optCfgs, v, "", "")
+}
+
+// appendOptCfgsFor is the function that appends the OptCfg(s) made from the fields of the struct
+// value to the OptCfg array.
+// The name prefix is prepended to the option names, and the key prefix is prepended to the store
+// keys.
+func appendOptCfgsFor(
+	optCfgs []OptCfg, v reflect.Value, namePrefix string, keyPrefix string,
+) ([]OptCfg, error) {
 	t := v.Type()
-	n := t.NumField()
 
-	optCfgs := make([]OptCfg, n)
+	var optNames, fldNames map[string]bool
+	if len(namePrefix) > 0 || len(keyPrefix) > 0 {
+		optNames = make(map[string]bool)
+		fldNames = make(map[string]bool)
+		collectSiblingNames(t, optNames, fldNames)
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		fld := t.Field(i)
+		if _, isPos := fld.Tag.Lookup("optpos"); isPos {
+			continue
+		}
 
-	for i := 0; i < n; i++ {
-		optCfgs[i] = newOptCfg(t.Field(i))
+		if isNestedStructType(fld.Type) {
+			prefix, hasPrefix := fld.Tag.Lookup("optprefix")
+			if !hasPrefix && !fld.Anonymous {
+				prefix = fld.Name + "-"
+			}
+			prefix = namePrefix + prefix
+			key := keyPrefix
+			if !fld.Anonymous {
+				key += fld.Name + "."
+			}
+			var err error
+			optCfgs, err = appendOptCfgsFor(optCfgs, v.Field(i), prefix, key)
+			if err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		cfg := newOptCfg(fld)
+		cfg.StoreKey = keyPrefix + cfg.StoreKey
+		if len(namePrefix) > 0 || len(keyPrefix) > 0 {
+			if len(cfg.Names) == 0 {
+				cfg.Names = []string{namePrefix + fld.Name}
+			} else {
+				for j, nm := range cfg.Names {
+					cfg.Names[j] = namePrefix + nm
+				}
+			}
+			for j, nm := range cfg.Requires {
+				if optNames[nm] {
+					cfg.Requires[j] = namePrefix + nm
+				} else if fldNames[nm] {
+					cfg.Requires[j] = keyPrefix + nm
+				}
+			}
+			if len(cfg.ExclusiveGroup) > 0 {
+				cfg.ExclusiveGroup = namePrefix + cfg.ExclusiveGroup
+			}
+		}
+		if cfg.HasArg && cfg.Defaults == nil {
+			cfg.DefaultInHelp = defaultInHelpOf(v.Field(i))
+		}
 
 		var optName string
-		if len(optCfgs[i].Names) > 0 {
-			optName = optCfgs[i].Names[0]
+		if len(cfg.Names) > 0 {
+			optName = cfg.Names[0]
 		} else {
-			optName = optCfgs[i].StoreKey
+			optName = cfg.StoreKey
 		}
 
-		setter, err := newValueSetter(optName, t.Field(i).Name, v.Field(i))
+		setter, err := newValueSetter(optName, cfg.StoreKey, v.Field(i), fld.Tag.Get("optlayout"))
 		if err != nil {
 			return nil, err
 		}
-		optCfgs[i].onParsed = &setter
+		cfg.onParsed = &setter
+		optCfgs = append(optCfgs, cfg)
 	}
 
 	return optCfgs, nil
 }
 
+// collectSiblingNames is the function that collects the option names and the field names of the
+// fields in the struct type, including the fields of embedded structs without the struct tag
+// optprefix.
+// These names are used to resolve the names in the struct tag optrequires which refer to the
+// options in the same struct.
+func collectSiblingNames(t reflect.Type, optNames map[string]bool, fldNames map[string]bool) {
+	for i := 0; i < t.NumField(); i++ {
+		fld := t.Field(i)
+		if _, isPos := fld.Tag.Lookup("optpos"); isPos {
+			continue
+		}
+		if isNestedStructType(fld.Type) {
+			if _, hasPrefix := fld.Tag.Lookup("optprefix"); fld.Anonymous && !hasPrefix {
+				collectSiblingNames(fld.Type, optNames, fldNames)
+			}
+			continue
+		}
+		fldNames[fld.Name] = true
+		names := strings.SplitN(fld.Tag.Get("optcfg"), "=", 2)[0]
+		for _, nm := range strings.Split(names, ",") {
+			if len(nm) > 0 {
+				optNames[nm] = true
+			}
+		}
+	}
+}
+
+// isNestedStructType is the function that checks whether the specified type is a struct type of
+// which fields are handled as options.
+// The struct types which are converted from option arguments, like time.Time, are excluded.
+func isNestedStructType(t reflect.Type) bool {
+	return t.Kind() == reflect.Struct && !isParsableType(t) && !isSelfSettableType(t)
+}
+
 func newOptCfg(fld reflect.StructField) OptCfg {
 	storeKey := fld.Name
 
@@ -128,20 +301,30 @@ func newOptCfg(fld reflect.StructField) OptCfg {
 		names = []string{}
 	}
 
+	typ := fld.Type
+	if isNilableType(typ) {
+		typ = typ.Elem()
+	}
+
 	isArray := false
+	isMap := false
 	hasArg := true
-	switch fld.Type.Kind() {
-	case reflect.Slice | reflect.Array:
-		isArray = true
-	case reflect.Bool:
-		hasArg = false
+	if !isParsableType(typ) && !isSelfSettableType(typ) {
+		switch typ.Kind() {
+		case reflect.Slice | reflect.Array:
+			isArray = true
+		case reflect.Map:
+			isMap = true
+		case reflect.Bool:
+			hasArg = false
+		}
 	}
 
 	var defaults []string
 	if len(arr) > 1 && hasArg {
 		def := arr[1]
 		n := len(def)
-		if !isArray {
+		if !isArray && !isMap {
 			defaults = []string{def}
 		} else if n > 1 && def[0] == '[' && def[n-1] == ']' {
 			defs := def[1 : n-1]
@@ -167,16 +350,47 @@ func newOptCfg(fld reflect.StructField) OptCfg {
 		optArg = fld.Tag.Get("optarg")
 	}
 
+	var envVars []string
+	env := fld.Tag.Get("optenv")
+	if len(env) > 0 {
+		envVars = strings.Split(env, ",")
+	}
+
+	isConfigFile, _ := strconv.ParseBool(fld.Tag.Get("optconfig"))
+	isRequired, _ := strconv.ParseBool(fld.Tag.Get("optreq"))
+	rejectsDupKey, _ := strconv.ParseBool(fld.Tag.Get("optnodupkey"))
+
+	exclusiveGroup := fld.Tag.Get("optexcl")
+
+	var requires []string
+	req := fld.Tag.Get("optrequires")
+	if len(req) > 0 {
+		requires = strings.Split(req, ",")
+	}
+
+	action := fld.Tag.Get("optaction")
+
 	desc := fld.Tag.Get("optdesc")
+	group := fld.Tag.Get("optgroup")
 
 	return OptCfg{
-		StoreKey:  storeKey,
-		Names:     names,
-		HasArg:    hasArg,
-		IsArray:   isArray,
-		Defaults:  defaults,
-		Desc:      desc,
-		ArgInHelp: optArg,
+		StoreKey:       storeKey,
+		Names:          names,
+		HasArg:         hasArg,
+		IsArray:        isArray,
+		IsMap:          isMap,
+		RejectsDupKey:  rejectsDupKey,
+		Defaults:       defaults,
+		EnvVars:        envVars,
+		IsConfigFile:   isConfigFile,
+		Required:       isRequired,
+		ExclusiveGroup: exclusiveGroup,
+		Requires:       requires,
+		IsHelp:         action == "help",
+		IsVersion:      action == "version",
+		Desc:           desc,
+		ArgInHelp:      optArg,
+		Group:          group,
 	}
 }
 
@@ -184,9 +398,20 @@ func newValueSetter(
 	optName string,
 	fldName string,
 	fld reflect.Value,
+	layout string,
 ) (func([]string) error, error) {
 	t := fld.Type()
+	if isParsableType(t) {
+		return newTypeSetter(optName, fldName, fld, layout)
+	}
+	if isSelfSettableType(t) {
+		return newSelfSetter(optName, fldName, fld)
+	}
 	switch t.Kind() {
+	case reflect.Ptr:
+		return newPtrSetter(optName, fldName, fld, layout)
+	case reflect.Map:
+		return newMapSetter(optName, fldName, fld, layout)
 	case reflect.Bool:
 		return newBoolSetter(optName, fldName, fld)
 	case reflect.Int:
@@ -215,6 +440,12 @@ func newValueSetter(
 		return newFloatSetter(optName, fldName, fld, 64)
 	case reflect.Array | reflect.Slice:
 		elm := t.Elem()
+		if isParsableType(elm) {
+			return newTypeArraySetter(optName, fldName, fld, layout)
+		}
+		if isSelfSettableType(elm) {
+			return newSelfArraySetter(optName, fldName, fld)
+		}
 		switch elm.Kind() {
 		case reflect.Int:
 			return newIntArraySetter(optName, fldName, fld, strconv.IntSize)
@@ -252,6 +483,97 @@ func newValueSetter(
 	}
 }
 
+// isNilableType is the function that checks whether the specified type is a pointer type of which
+// field is left nil when the option is not given.
+// The pointer types which are converted from option arguments as they are, like *url.URL, are
+// excluded.
+func isNilableType(t reflect.Type) bool {
+	return t.Kind() == reflect.Ptr && !isParsableType(t) && !isSelfSettableType(t)
+}
+
+func newPtrSetter(
+	optName string, fldName string, fld reflect.Value, layout string,
+) (func([]string) error, error) {
+	elm := fld.Type().Elem()
+	if elm.Kind() == reflect.Ptr {
+		return newBadFieldTypeError(optName, fldName, fld.Type())
+	}
+	_, err := newValueSetter(optName, fldName, reflect.New(elm).Elem(), layout)
+	if err != nil {
+		return nil, err
+	}
+	fn := func(s []string) error {
+		if s == nil && elm.Kind() != reflect.Bool {
+			return nil
+		}
+		ptr := reflect.New(elm)
+		setter, _ := newValueSetter(optName, fldName, ptr.Elem(), layout)
+		e := setter(s)
+		if e != nil {
+			return e
+		}
+		fld.Set(ptr)
+		return nil
+	}
+	return fn, nil
+}
+
+// newMapSetter is the function that creates a setter for a map field of which key type is string.
+// Each option argument of the form of key=value is set to the map with the value converted to the
+// element type of the map, and the last value wins if a key is given multiple times.
+func newMapSetter(
+	optName string, fldName string, fld reflect.Value, layout string,
+) (func([]string) error, error) {
+	t := fld.Type()
+	elm := t.Elem()
+	if t.Key().Kind() != reflect.String {
+		return newBadFieldTypeError(optName, fldName, t)
+	}
+	if !isParsableType(elm) && !isSelfSettableType(elm) {
+		switch elm.Kind() {
+		case reflect.Slice, reflect.Array, reflect.Map:
+			return newBadFieldTypeError(optName, fldName, t)
+		}
+	}
+	_, err := newValueSetter(optName, fldName, reflect.New(elm).Elem(), layout)
+	if err != nil {
+		return nil, err
+	}
+	fn := func(s []string) error {
+		if s == nil {
+			return nil
+		}
+		m := reflect.MakeMapWithSize(t, len(s))
+		for _, a := range s {
+			k, v, found := strings.Cut(a, "=")
+			if !found {
+				return errors.OptionArgIsInvalid{
+					Option: optName, StoreKey: fldName, OptArg: a, TypeKind: reflect.Map,
+					Cause: errors.OptArgIsNotKeyValue{OptArg: a}}
+			}
+			val := reflect.New(elm).Elem()
+			if elm.Kind() == reflect.Bool {
+				b, e := strconv.ParseBool(v)
+				if e != nil {
+					return errors.OptionArgIsInvalid{
+						Option: optName, StoreKey: fldName, OptArg: a, TypeKind: reflect.Bool, Cause: e}
+				}
+				val.SetBool(b)
+			} else {
+				setter, _ := newValueSetter(optName, fldName, val, layout)
+				e := setter([]string{v})
+				if e != nil {
+					return e
+				}
+			}
+			m.SetMapIndex(reflect.ValueOf(k).Convert(t.Key()), val)
+		}
+		fld.Set(m)
+		return nil
+	}
+	return fn, nil
+}
+
 func newBadFieldTypeError(
 	optName string, fldName string, t reflect.Type,
 ) (func([]string) error, error) {
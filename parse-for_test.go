@@ -2,9 +2,15 @@ package cliargs_test
 
 import (
 	"errors"
+	"net"
+	"net/netip"
+	"net/url"
 	"os"
+	"path/filepath"
 	"reflect"
+	"regexp"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/sttk/cliargs"
@@ -1885,7 +1891,7 @@ func TestMakeOptCfgsFor_optionParam(t *testing.T) {
 func TestParseFor_optCfgHasUnsupportedType(t *testing.T) {
 	defer reset()
 
-	type A struct{ Name string }
+	type A complex128
 	type MyOptions struct {
 		FooBar A `optcfg:"foo-bar,f" optdesc:"FooBar description"`
 	}
@@ -2001,7 +2007,7 @@ func TestParseUntilSubCmdFor_noSubCmd(t *testing.T) {
 func TestParseUntilSubCmdFor_error0(t *testing.T) {
 	defer reset()
 
-	type A struct{}
+	type A complex128
 	type MyOptions struct {
 		FooBar A `optcfg:"foo-bar,f"`
 	}
@@ -2025,7 +2031,7 @@ func TestParseUntilSubCmdFor_error0(t *testing.T) {
 	assert.Equal(t, cmd.Args, []string{})
 	assert.False(t, cmd.HasOpt("FooBar"))
 
-	assert.Equal(t, options.FooBar, A{})
+	assert.Equal(t, options.FooBar, A(0))
 
 	assert.Equal(t, subCmd.Name, "")
 	assert.Equal(t, subCmd.Args, []string(nil))
@@ -2064,3 +2070,877 @@ func TestParseUntilSubCmdFor_error1(t *testing.T) {
 	assert.Equal(t, subCmd.Name, "qux")
 	assert.Equal(t, subCmd.Args, []string{})
 }
+
+func TestParseFor_withCmdFromArgs(t *testing.T) {
+	type MyOptions struct {
+		FooBar bool `optcfg:"foo-bar,f"`
+		Baz    int  `optcfg:"baz"`
+	}
+	options := MyOptions{}
+
+	cmd := cliargs.NewCmdFromArgs("app", []string{"-f", "qux", "--baz", "1"})
+	err := cmd.ParseFor(&options)
+
+	assert.Nil(t, err)
+	assert.Equal(t, cmd.Name, "app")
+	assert.Equal(t, cmd.Args, []string{"qux"})
+	assert.True(t, options.FooBar)
+	assert.Equal(t, options.Baz, 1)
+}
+
+func TestParseUntilSubCmdFor_withCmdFromArgs(t *testing.T) {
+	type MyOptions struct {
+		FooBar bool `optcfg:"foo-bar,f"`
+	}
+	options := MyOptions{}
+
+	cmd := cliargs.NewCmdFromArgs("app", []string{"-f", "qux", "--baz"})
+	subCmd, err := cmd.ParseUntilSubCmdFor(&options)
+
+	assert.Nil(t, err)
+	assert.True(t, options.FooBar)
+	assert.Equal(t, subCmd.Name, "qux")
+
+	err = subCmd.Parse()
+	assert.Nil(t, err)
+	assert.True(t, subCmd.HasOpt("baz"))
+}
+
+func TestParseFor_collectAllErrs(t *testing.T) {
+	type MyOptions struct {
+		FooBar bool    `optcfg:"foo-bar,f"`
+		Baz    int     `optcfg:"baz"`
+		Qux    []uint  `optcfg:"qux"`
+		Quux   float64 `optcfg:"quux=abc"`
+	}
+	options := MyOptions{}
+
+	cmd := cliargs.NewCmdFromArgs("app", []string{"-f", "--corge", "--baz", "x", "--qux=-1"})
+	cmd.SetCollectsAllErrs(true)
+	err := cmd.ParseFor(&options)
+
+	switch e := err.(type) {
+	case errs.ErrorList:
+		assert.Equal(t, len(e.Errs), 4)
+		assert.Equal(t, e.Errs[0], errs.UnconfiguredOption{Option: "corge"})
+		assert.Equal(t, e.Errs[1].(errs.OptionArgIsInvalid).StoreKey, "Baz")
+		assert.Equal(t, e.Errs[1].(errs.OptionArgIsInvalid).OptArg, "x")
+		assert.Equal(t, e.Errs[2].(errs.OptionArgIsInvalid).StoreKey, "Qux")
+		assert.Equal(t, e.Errs[2].(errs.OptionArgIsInvalid).OptArg, "-1")
+		assert.Equal(t, e.Errs[3].(errs.OptionArgIsInvalid).StoreKey, "Quux")
+		assert.Equal(t, e.Errs[3].(errs.OptionArgIsInvalid).OptArg, "abc")
+	default:
+		assert.Fail(t, err.Error())
+	}
+
+	assert.True(t, options.FooBar)
+}
+
+func TestParseFor_collectAllErrsIfOptionStoreIsBad(t *testing.T) {
+	type MyOptions struct {
+		FooBar bool `optcfg:"foo-bar,f"`
+	}
+	options := MyOptions{}
+
+	cmd := cliargs.NewCmdFromArgs("app", []string{"-f"})
+	cmd.SetCollectsAllErrs(true)
+	err := cmd.ParseFor(options)
+
+	assert.Equal(t, err, errs.ErrorList{Errs: []error{errs.OptionStoreIsNotChangeable{}}})
+	assert.True(t, errors.Is(err, errs.OptionStoreIsNotChangeable{}))
+}
+
+func TestParseFor_envVars(t *testing.T) {
+	t.Setenv("APP_BAZ", "12")
+	t.Setenv("APP_QUX", "A:B,C")
+	t.Setenv("APP_FOO_BAR", "1")
+
+	type MyOptions struct {
+		FooBar bool     `optcfg:"foo-bar,f" optenv:"APP_FOO_BAR"`
+		Baz    int      `optcfg:"baz=99" optenv:"APP_BAZ0,APP_BAZ"`
+		Qux    []string `optcfg:"qux" optenv:"APP_QUX"`
+		Quux   int      `optcfg:"quux=99" optenv:"APP_QUUX"`
+	}
+	options := MyOptions{}
+
+	cmd := cliargs.NewCmdFromArgs("app", []string{})
+	err := cmd.ParseFor(&options)
+
+	assert.Nil(t, err)
+	assert.True(t, options.FooBar)
+	assert.Equal(t, options.Baz, 12)
+	assert.Equal(t, options.Qux, []string{"A:B", "C"})
+	assert.Equal(t, options.Quux, 99)
+
+	assert.Equal(t, cmd.OptCfgs[0].EnvVars, []string{"APP_FOO_BAR"})
+	assert.Equal(t, cmd.OptCfgs[1].EnvVars, []string{"APP_BAZ0", "APP_BAZ"})
+	assert.Equal(t, cmd.OptCfgs[2].EnvVars, []string{"APP_QUX"})
+	assert.Equal(t, cmd.OptCfgs[3].EnvVars, []string{"APP_QUUX"})
+}
+
+func TestParseFor_envVarIsInvalidType(t *testing.T) {
+	t.Setenv("APP_BAZ", "abc")
+
+	type MyOptions struct {
+		Baz int `optcfg:"baz" optenv:"APP_BAZ"`
+	}
+	options := MyOptions{}
+
+	cmd := cliargs.NewCmdFromArgs("app", []string{})
+	err := cmd.ParseFor(&options)
+
+	switch e := err.(type) {
+	case errs.OptionArgIsInvalid:
+		assert.Equal(t, e.StoreKey, "Baz")
+		assert.Equal(t, e.OptArg, "abc")
+		assert.Equal(t, e.TypeKind, reflect.Int)
+	default:
+		assert.Fail(t, err.Error())
+	}
+}
+
+func TestParseFor_configFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.toml")
+	os.WriteFile(path, []byte(`
+FooBar = true
+Baz = 0x10
+Qux = [1.5, -2]
+Quux = "hello"
+`), 0644)
+
+	type MyOptions struct {
+		Config string    `optcfg:"config" optconfig:"true"`
+		FooBar bool      `optcfg:"foo-bar"`
+		Baz    int       `optcfg:"baz=99"`
+		Qux    []float64 `optcfg:"qux"`
+		Quux   string    `optcfg:"quux"`
+	}
+	options := MyOptions{}
+
+	cmd := cliargs.NewCmdFromArgs("app", []string{"--config", path, "--quux", "bye"})
+	err := cmd.ParseFor(&options)
+
+	assert.Nil(t, err)
+	assert.True(t, cmd.OptCfgs[0].IsConfigFile)
+	assert.Equal(t, options.Config, path)
+	assert.True(t, options.FooBar)
+	assert.Equal(t, options.Baz, 16)
+	assert.Equal(t, options.Qux, []float64{1.5, -2})
+	assert.Equal(t, options.Quux, "bye")
+}
+
+func TestParseFor_configFileHasValueOfInvalidType(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.json")
+	os.WriteFile(path, []byte(`{"Baz": "abc"}`), 0644)
+
+	type MyOptions struct {
+		Config string `optcfg:"config" optconfig:"true"`
+		Baz    int    `optcfg:"baz"`
+	}
+	options := MyOptions{}
+
+	cmd := cliargs.NewCmdFromArgs("app", []string{"--config", path})
+	err := cmd.ParseFor(&options)
+
+	switch e := err.(type) {
+	case errs.OptionArgIsInvalid:
+		assert.Equal(t, e.StoreKey, "Baz")
+		assert.Equal(t, e.OptArg, "abc")
+	default:
+		assert.Fail(t, err.Error())
+	}
+}
+
+func TestParseFor_attachedShortOptArg(t *testing.T) {
+	type MyOptions struct {
+		Num     int      `optcfg:"n"`
+		Include []string `optcfg:"I"`
+		Verbose bool     `optcfg:"v"`
+	}
+	options := MyOptions{}
+
+	cmd := cliargs.NewCmdFromArgs("app", []string{"-vn12", "-Ia", "-Ib"})
+	err := cmd.ParseFor(&options)
+
+	assert.Nil(t, err)
+	assert.Equal(t, options.Num, 12)
+	assert.Equal(t, options.Include, []string{"a", "b"})
+	assert.True(t, options.Verbose)
+}
+
+func TestParseFor_digitShortOptAndNegativeNumber(t *testing.T) {
+	type MyOptions struct {
+		Kill   bool `optcfg:"kill,9"`
+		Offset int  `optcfg:"offset,o"`
+	}
+	options := MyOptions{}
+
+	cmd := cliargs.NewCmdFromArgs("app", []string{"-9", "--offset", "-3", "pid"})
+	err := cmd.ParseFor(&options)
+
+	assert.Nil(t, err)
+	assert.Equal(t, cmd.Args, []string{"pid"})
+	assert.True(t, options.Kill)
+	assert.Equal(t, options.Offset, -3)
+}
+
+func TestParseFor_requiredOption(t *testing.T) {
+	type MyOptions struct {
+		Foo string `optcfg:"foo,f" optreq:"true"`
+		Bar bool   `optreq:"false"`
+		Baz []int  `optcfg:"baz" optreq:"true"`
+	}
+	options := MyOptions{}
+
+	cmd := cliargs.NewCmdFromArgs("app", []string{"--foo", "x"})
+	err := cmd.ParseFor(&options)
+
+	switch e := err.(type) {
+	case errs.MissingRequiredOption:
+		assert.Equal(t, e.Options, []string{"baz"})
+	default:
+		assert.Fail(t, err.Error())
+	}
+	assert.True(t, cmd.OptCfgs[0].Required)
+	assert.False(t, cmd.OptCfgs[1].Required)
+	assert.True(t, cmd.OptCfgs[2].Required)
+	assert.Equal(t, options.Foo, "x")
+}
+
+func TestParseFor_exclusiveGroupAndRequires(t *testing.T) {
+	type MyOptions struct {
+		Json     bool   `optcfg:"json" optexcl:"format"`
+		Yaml     bool   `optcfg:"yaml" optexcl:"format"`
+		User     string `optcfg:"user" optrequires:"password"`
+		Password string `optcfg:"password"`
+	}
+	options := MyOptions{}
+
+	cmd := cliargs.NewCmdFromArgs("app", []string{"--json", "--yaml"})
+	err := cmd.ParseFor(&options)
+
+	switch e := err.(type) {
+	case errs.OptionsAreExclusive:
+		assert.Equal(t, e.Group, "format")
+		assert.Equal(t, e.Options, []string{"json", "yaml"})
+	default:
+		assert.Fail(t, err.Error())
+	}
+	assert.Equal(t, cmd.OptCfgs[0].ExclusiveGroup, "format")
+	assert.Equal(t, cmd.OptCfgs[2].Requires, []string{"password"})
+	assert.Nil(t, cmd.OptCfgs[3].Requires)
+
+	options = MyOptions{}
+	cmd = cliargs.NewCmdFromArgs("app", []string{"--user", "me"})
+	err = cmd.ParseFor(&options)
+
+	switch e := err.(type) {
+	case errs.OptionRequiresOthers:
+		assert.Equal(t, e.Option, "user")
+		assert.Equal(t, e.Missing, []string{"password"})
+	default:
+		assert.Fail(t, err.Error())
+	}
+}
+
+func TestParseFor_optgroup(t *testing.T) {
+	type MyOptions struct {
+		Host    string `optcfg:"host" optgroup:"Network options"`
+		Verbose bool   `optcfg:"verbose"`
+	}
+	options := MyOptions{}
+
+	cmd := cliargs.NewCmdFromArgs("app", []string{})
+	err := cmd.ParseFor(&options)
+
+	assert.Nil(t, err)
+	assert.Equal(t, cmd.OptCfgs[0].Group, "Network options")
+	assert.Equal(t, cmd.OptCfgs[1].Group, "")
+}
+
+func TestParseFor_optaction(t *testing.T) {
+	type MyOptions struct {
+		Help    bool   `optcfg:"help,h" optaction:"help"`
+		Version bool   `optcfg:"version" optaction:"version"`
+		File    string `optcfg:"file" optreq:"true"`
+	}
+	options := MyOptions{}
+
+	cmd := cliargs.NewCmdFromArgs("app", []string{"-h"})
+	err := cmd.ParseFor(&options)
+
+	assert.Equal(t, err, errs.HelpIsRequested{Option: "help"})
+	assert.True(t, options.Help)
+	assert.False(t, options.Version)
+	assert.True(t, cmd.OptCfgs[0].IsHelp)
+	assert.False(t, cmd.OptCfgs[0].IsVersion)
+	assert.True(t, cmd.OptCfgs[1].IsVersion)
+
+	options = MyOptions{}
+	cmd = cliargs.NewCmdFromArgs("app", []string{"--version"})
+	err = cmd.ParseFor(&options)
+
+	assert.Equal(t, err, errs.VersionIsRequested{Option: "version"})
+	assert.True(t, options.Version)
+}
+
+func TestParseFor_stdTypes(t *testing.T) {
+	type MyOptions struct {
+		Timeout  time.Duration    `optcfg:"timeout=5s"`
+		Since    time.Time        `optcfg:"since" optlayout:"2006-01-02"`
+		Until    time.Time        `optcfg:"until"`
+		Addr     net.IP           `optcfg:"addr"`
+		Network  net.IPNet        `optcfg:"network"`
+		Host     netip.Addr       `optcfg:"host"`
+		Prefix   netip.Prefix     `optcfg:"prefix"`
+		Endpoint *url.URL         `optcfg:"endpoint"`
+		Pattern  *regexp.Regexp   `optcfg:"pattern"`
+		Mode     os.FileMode      `optcfg:"mode=0644"`
+		Retries  []time.Duration  `optcfg:"retry"`
+		Allows   []netip.Prefix   `optcfg:"allow"`
+		Patterns []*regexp.Regexp `optcfg:"patterns"`
+	}
+	options := MyOptions{}
+
+	cmd := cliargs.NewCmdFromArgs("app", []string{
+		"--since", "2024-03-15", "--until", "2024-03-16T10:20:30Z",
+		"--addr", "192.168.0.1", "--network", "10.0.0.0/8",
+		"--host", "::1", "--prefix", "fd00::/8",
+		"--endpoint", "https://example.com/api?x=1", "--pattern", "^a.*z$",
+		"--retry", "1s", "--retry", "1m30s", "--allow", "10.0.0.0/8", "--allow", "::/0",
+		"--patterns", "a+", "--patterns", "b?",
+	})
+	err := cmd.ParseFor(&options)
+	assert.Nil(t, err)
+
+	assert.Equal(t, options.Timeout, 5*time.Second)
+	assert.Equal(t, options.Since, time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC))
+	assert.Equal(t, options.Until, time.Date(2024, 3, 16, 10, 20, 30, 0, time.UTC))
+	assert.True(t, options.Addr.Equal(net.IPv4(192, 168, 0, 1)))
+	assert.Equal(t, options.Network.String(), "10.0.0.0/8")
+	assert.Equal(t, options.Host, netip.MustParseAddr("::1"))
+	assert.Equal(t, options.Prefix, netip.MustParsePrefix("fd00::/8"))
+	assert.Equal(t, options.Endpoint.Host, "example.com")
+	assert.Equal(t, options.Endpoint.Query().Get("x"), "1")
+	assert.True(t, options.Pattern.MatchString("abcz"))
+	assert.Equal(t, options.Mode, os.FileMode(0644))
+	assert.Equal(t, options.Retries, []time.Duration{time.Second, 90 * time.Second})
+	assert.Equal(t, options.Allows, []netip.Prefix{
+		netip.MustParsePrefix("10.0.0.0/8"), netip.MustParsePrefix("::/0")})
+	assert.Equal(t, len(options.Patterns), 2)
+	assert.True(t, options.Patterns[1].MatchString("b"))
+
+	assert.True(t, cmd.OptCfgs[0].HasArg)
+	assert.False(t, cmd.OptCfgs[0].IsArray)
+	assert.True(t, cmd.OptCfgs[3].HasArg)
+	assert.False(t, cmd.OptCfgs[3].IsArray)
+	assert.True(t, cmd.OptCfgs[10].IsArray)
+}
+
+func TestParseFor_stdTypes_invalid(t *testing.T) {
+	type MyOptions struct {
+		Timeout time.Duration   `optcfg:"timeout"`
+		Addr    net.IP          `optcfg:"addr"`
+		Mode    os.FileMode     `optcfg:"mode"`
+		Retries []time.Duration `optcfg:"retry"`
+	}
+	options := MyOptions{}
+
+	cmd := cliargs.NewCmdFromArgs("app", []string{"--timeout", "30"})
+	err := cmd.ParseFor(&options)
+	switch e := err.(type) {
+	case errs.OptionArgIsInvalid:
+		assert.Equal(t, e.Option, "timeout")
+		assert.Equal(t, e.StoreKey, "Timeout")
+		assert.Equal(t, e.OptArg, "30")
+		assert.Equal(t, e.TypeKind, reflect.Int64)
+	default:
+		assert.Fail(t, err.Error())
+	}
+
+	options = MyOptions{}
+	cmd = cliargs.NewCmdFromArgs("app", []string{"--addr", "1.2.3"})
+	err = cmd.ParseFor(&options)
+	switch e := err.(type) {
+	case errs.OptionArgIsInvalid:
+		assert.Equal(t, e.OptArg, "1.2.3")
+		assert.Equal(t, e.Cause.Error(), "invalid IP address: 1.2.3")
+	default:
+		assert.Fail(t, err.Error())
+	}
+
+	options = MyOptions{}
+	cmd = cliargs.NewCmdFromArgs("app", []string{"--mode", "0999"})
+	err = cmd.ParseFor(&options)
+	switch e := err.(type) {
+	case errs.OptionArgIsInvalid:
+		assert.Equal(t, e.OptArg, "0999")
+	default:
+		assert.Fail(t, err.Error())
+	}
+
+	options = MyOptions{}
+	cmd = cliargs.NewCmdFromArgs("app", []string{"--retry", "1s", "--retry", "x"})
+	err = cmd.ParseFor(&options)
+	switch e := err.(type) {
+	case errs.OptionArgIsInvalid:
+		assert.Equal(t, e.OptArg, "x")
+	default:
+		assert.Fail(t, err.Error())
+	}
+}
+
+func TestParseFor_stdTypes_cmdArgs(t *testing.T) {
+	type MyOptions struct {
+		Host  net.IP          `optpos:"0"`
+		Waits []time.Duration `optpos:"rest"`
+	}
+	options := MyOptions{}
+
+	cmd := cliargs.NewCmdFromArgs("app", []string{"127.0.0.1", "1s", "2s"})
+	err := cmd.ParseFor(&options)
+	assert.Nil(t, err)
+	assert.Equal(t, options.Host.String(), "127.0.0.1")
+	assert.Equal(t, options.Waits, []time.Duration{time.Second, 2 * time.Second})
+}
+
+func TestParseFor_pointerFields(t *testing.T) {
+	type MyOptions struct {
+		Port    *int           `optcfg:"port"`
+		Name    *string        `optcfg:"name"`
+		Verbose *bool          `optcfg:"verbose"`
+		Timeout *time.Duration `optcfg:"timeout"`
+		Ratio   *float64       `optcfg:"ratio=0.5"`
+		Tags    *[]string      `optcfg:"tag"`
+		Level   *logLevel      `optcfg:"level"`
+		Host    *net.IP        `optcfg:"host"`
+	}
+
+	options := MyOptions{}
+	cmd := cliargs.NewCmdFromArgs("app", []string{})
+	err := cmd.ParseFor(&options)
+
+	assert.Nil(t, err)
+	assert.Nil(t, options.Port)
+	assert.Nil(t, options.Name)
+	assert.Nil(t, options.Verbose)
+	assert.Nil(t, options.Timeout)
+	assert.Equal(t, *options.Ratio, 0.5)
+	assert.Nil(t, options.Tags)
+	assert.Nil(t, options.Level)
+	assert.Nil(t, options.Host)
+
+	assert.True(t, cmd.OptCfgs[0].HasArg)
+	assert.False(t, cmd.OptCfgs[0].IsArray)
+	assert.False(t, cmd.OptCfgs[2].HasArg)
+	assert.True(t, cmd.OptCfgs[5].IsArray)
+
+	options = MyOptions{}
+	cmd = cliargs.NewCmdFromArgs("app", []string{
+		"--port", "0", "--name", "", "--verbose", "--timeout", "3s",
+		"--tag", "a", "--tag", "b", "--level", "error", "--host", "::1"})
+	err = cmd.ParseFor(&options)
+
+	assert.Nil(t, err)
+	assert.Equal(t, *options.Port, 0)
+	assert.Equal(t, *options.Name, "")
+	assert.True(t, *options.Verbose)
+	assert.Equal(t, *options.Timeout, 3*time.Second)
+	assert.Equal(t, *options.Tags, []string{"a", "b"})
+	assert.Equal(t, *options.Level, levelError)
+	assert.Equal(t, options.Host.String(), "::1")
+}
+
+func TestParseFor_pointerFields_invalid(t *testing.T) {
+	type MyOptions struct {
+		Port *int `optcfg:"port"`
+	}
+	options := MyOptions{}
+
+	cmd := cliargs.NewCmdFromArgs("app", []string{"--port", "x"})
+	err := cmd.ParseFor(&options)
+
+	switch e := err.(type) {
+	case errs.OptionArgIsInvalid:
+		assert.Equal(t, e.Option, "port")
+		assert.Equal(t, e.OptArg, "x")
+		assert.Equal(t, e.TypeKind, reflect.Int)
+	default:
+		assert.Fail(t, err.Error())
+	}
+	assert.Nil(t, options.Port)
+
+	type BadOptions struct {
+		Port **int     `optcfg:"port"`
+		Ch   *chan int `optcfg:"ch"`
+	}
+	bad := BadOptions{}
+
+	cmd = cliargs.NewCmdFromArgs("app", []string{})
+	err = cmd.ParseFor(&bad)
+
+	switch e := err.(type) {
+	case errs.BadFieldType:
+		assert.Equal(t, e.Option, "port")
+		assert.Equal(t, e.Type, reflect.TypeOf(bad.Port))
+	default:
+		assert.Fail(t, err.Error())
+	}
+}
+
+func TestParseFor_mapFields(t *testing.T) {
+	type MyOptions struct {
+		Labels  map[string]string        `optcfg:"label,l"`
+		Limits  map[string]int           `optcfg:"limit=[cpu=1,mem=512]"`
+		Flags   map[string]bool          `optcfg:"flag"`
+		Timeout map[string]time.Duration `optcfg:"timeout" optnodupkey:"true"`
+	}
+	options := MyOptions{}
+
+	cmd := cliargs.NewCmdFromArgs("app", []string{
+		"--label", "env=prod", "-l", "tier=web", "-l", "env=dev",
+		"--flag", "a=true", "--flag", "b=0", "--timeout", "read=3s"})
+	err := cmd.ParseFor(&options)
+
+	assert.Nil(t, err)
+	assert.Equal(t, options.Labels, map[string]string{"env": "dev", "tier": "web"})
+	assert.Equal(t, options.Limits, map[string]int{"cpu": 1, "mem": 512})
+	assert.Equal(t, options.Flags, map[string]bool{"a": true, "b": false})
+	assert.Equal(t, options.Timeout, map[string]time.Duration{"read": 3 * time.Second})
+
+	assert.True(t, cmd.OptCfgs[0].HasArg)
+	assert.False(t, cmd.OptCfgs[0].IsArray)
+	assert.True(t, cmd.OptCfgs[0].IsMap)
+	assert.False(t, cmd.OptCfgs[0].RejectsDupKey)
+	assert.Equal(t, cmd.OptCfgs[1].Defaults, []string{"cpu=1", "mem=512"})
+	assert.True(t, cmd.OptCfgs[3].RejectsDupKey)
+}
+
+func TestParseFor_mapFields_invalid(t *testing.T) {
+	type MyOptions struct {
+		Limits  map[string]int           `optcfg:"limit"`
+		Timeout map[string]time.Duration `optcfg:"timeout" optnodupkey:"true"`
+	}
+	options := MyOptions{}
+
+	cmd := cliargs.NewCmdFromArgs("app", []string{"--limit", "cpu=x"})
+	err := cmd.ParseFor(&options)
+
+	switch e := err.(type) {
+	case errs.OptionArgIsInvalid:
+		assert.Equal(t, e.Option, "limit")
+		assert.Equal(t, e.OptArg, "x")
+		assert.Equal(t, e.TypeKind, reflect.Int)
+	default:
+		assert.Fail(t, err.Error())
+	}
+	assert.Nil(t, options.Limits)
+
+	cmd = cliargs.NewCmdFromArgs("app", []string{"--timeout", "read=1s", "--timeout", "read=2s"})
+	err = cmd.ParseFor(&options)
+	assert.Equal(t, err, errs.MapKeyIsDuplicated{StoreKey: "Timeout", Option: "timeout", Key: "read"})
+
+	type BadOptions struct {
+		ByNum map[int]string `optcfg:"by-num"`
+	}
+	bad := BadOptions{}
+
+	cmd = cliargs.NewCmdFromArgs("app", []string{})
+	err = cmd.ParseFor(&bad)
+
+	switch e := err.(type) {
+	case errs.BadFieldType:
+		assert.Equal(t, e.Option, "by-num")
+		assert.Equal(t, e.Type, reflect.TypeOf(bad.ByNum))
+	default:
+		assert.Fail(t, err.Error())
+	}
+
+	type BadOptions2 struct {
+		Lists map[string][]string `optcfg:"list"`
+	}
+	bad2 := BadOptions2{}
+
+	cmd = cliargs.NewCmdFromArgs("app", []string{})
+	err = cmd.ParseFor(&bad2)
+
+	switch e := err.(type) {
+	case errs.BadFieldType:
+		assert.Equal(t, e.Option, "list")
+	default:
+		assert.Fail(t, err.Error())
+	}
+}
+
+func TestParseFor_embeddedStruct(t *testing.T) {
+	type LogOptions struct {
+		Verbose bool   `optcfg:"verbose,v"`
+		LogFile string `optcfg:"log-file"`
+	}
+	type MyOptions struct {
+		LogOptions
+		Name string `optcfg:"name"`
+	}
+	options := MyOptions{}
+
+	cmd := cliargs.NewCmdFromArgs("app", []string{"-v", "--log-file", "a.log", "--name", "x"})
+	err := cmd.ParseFor(&options)
+
+	assert.Nil(t, err)
+	assert.True(t, options.Verbose)
+	assert.Equal(t, options.LogFile, "a.log")
+	assert.Equal(t, options.Name, "x")
+
+	assert.Equal(t, len(cmd.OptCfgs), 3)
+	assert.Equal(t, cmd.OptCfgs[0].StoreKey, "Verbose")
+	assert.Equal(t, cmd.OptCfgs[0].Names, []string{"verbose", "v"})
+	assert.Equal(t, cmd.OptCfgs[1].StoreKey, "LogFile")
+	assert.Equal(t, cmd.OptCfgs[2].StoreKey, "Name")
+	assert.True(t, cmd.HasOpt("Verbose"))
+	assert.Equal(t, cmd.OptArg("LogFile"), "a.log")
+}
+
+func TestParseFor_nestedStruct(t *testing.T) {
+	type DBOptions struct {
+		Host string `optcfg:"host=localhost"`
+		Port int    `optcfg:"port,p=5432"`
+		User string
+	}
+	type MyOptions struct {
+		DB    DBOptions `optprefix:"db-"`
+		Cache DBOptions `optprefix:"cache-"`
+		Since time.Time `optcfg:"since" optlayout:"2006-01-02"`
+	}
+	options := MyOptions{}
+
+	cmd := cliargs.NewCmdFromArgs("app", []string{
+		"--db-host", "db.example.com", "--db-User", "admin", "--cache-p", "6379",
+		"--since", "2024-01-02"})
+	err := cmd.ParseFor(&options)
+
+	assert.Nil(t, err)
+	assert.Equal(t, options.DB.Host, "db.example.com")
+	assert.Equal(t, options.DB.Port, 5432)
+	assert.Equal(t, options.DB.User, "admin")
+	assert.Equal(t, options.Cache.Host, "localhost")
+	assert.Equal(t, options.Cache.Port, 6379)
+	assert.Equal(t, options.Cache.User, "")
+	assert.Equal(t, options.Since, time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC))
+
+	assert.Equal(t, len(cmd.OptCfgs), 7)
+	assert.Equal(t, cmd.OptCfgs[0].StoreKey, "DB.Host")
+	assert.Equal(t, cmd.OptCfgs[0].Names, []string{"db-host"})
+	assert.Equal(t, cmd.OptCfgs[1].StoreKey, "DB.Port")
+	assert.Equal(t, cmd.OptCfgs[1].Names, []string{"db-port", "db-p"})
+	assert.Equal(t, cmd.OptCfgs[2].StoreKey, "DB.User")
+	assert.Equal(t, cmd.OptCfgs[2].Names, []string{"db-User"})
+	assert.Equal(t, cmd.OptCfgs[4].StoreKey, "Cache.Port")
+	assert.Equal(t, cmd.OptCfgs[4].Names, []string{"cache-port", "cache-p"})
+	assert.Equal(t, cmd.OptCfgs[6].StoreKey, "Since")
+	assert.Equal(t, cmd.OptArg("DB.Host"), "db.example.com")
+	assert.Equal(t, cmd.OptArg("Cache.Port"), "6379")
+}
+
+func TestParseFor_nestedStructWithoutPrefix(t *testing.T) {
+	type Inner struct {
+		Level int `optcfg:"level"`
+	}
+	type Outer struct {
+		Inner Inner `optprefix:"inner-"`
+	}
+	type MyOptions struct {
+		Outer Outer `optprefix:"outer-"`
+		Other Inner `optprefix:""`
+	}
+	options := MyOptions{}
+
+	cmd := cliargs.NewCmdFromArgs("app", []string{"--outer-inner-level", "1", "--level", "2"})
+	err := cmd.ParseFor(&options)
+
+	assert.Nil(t, err)
+	assert.Equal(t, options.Outer.Inner.Level, 1)
+	assert.Equal(t, options.Other.Level, 2)
+	assert.Equal(t, cmd.OptCfgs[0].StoreKey, "Outer.Inner.Level")
+	assert.Equal(t, cmd.OptCfgs[0].Names, []string{"outer-inner-level"})
+	assert.Equal(t, cmd.OptCfgs[1].StoreKey, "Other.Level")
+	assert.Equal(t, cmd.OptCfgs[1].Names, []string{"level"})
+}
+
+func TestParseFor_nestedStructFromConfigFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.json")
+	os.WriteFile(path, []byte(`{"DB": {"Host": "db.example.com", "Port": 3306}}`), 0644)
+
+	type DBOptions struct {
+		Host string `optcfg:"host"`
+		Port int    `optcfg:"port"`
+	}
+	type MyOptions struct {
+		Config string    `optcfg:"config" optconfig:"true"`
+		DB     DBOptions `optprefix:"db-"`
+	}
+	options := MyOptions{}
+
+	cmd := cliargs.NewCmdFromArgs("app", []string{"--config", path, "--db-port", "5432"})
+	err := cmd.ParseFor(&options)
+
+	assert.Nil(t, err)
+	assert.Equal(t, options.DB.Host, "db.example.com")
+	assert.Equal(t, options.DB.Port, 5432)
+}
+
+func TestParseFor_nestedStruct_invalid(t *testing.T) {
+	type DBOptions struct {
+		Port int `optcfg:"port"`
+		Ch   chan int
+	}
+	type MyOptions struct {
+		DB DBOptions `optprefix:"db-"`
+	}
+	options := MyOptions{}
+
+	_, err := cliargs.MakeOptCfgsFor(&options)
+
+	switch e := err.(type) {
+	case errs.BadFieldType:
+		assert.Equal(t, e.Option, "db-Ch")
+		assert.Equal(t, e.Field, "DB.Ch")
+	default:
+		assert.Fail(t, err.Error())
+	}
+
+	type MyOptions2 struct {
+		DB struct {
+			Port int `optcfg:"port"`
+		} `optprefix:"db-"`
+	}
+	options2 := MyOptions2{}
+
+	cmd := cliargs.NewCmdFromArgs("app", []string{"--db-port", "x"})
+	err = cmd.ParseFor(&options2)
+
+	switch e := err.(type) {
+	case errs.OptionArgIsInvalid:
+		assert.Equal(t, e.StoreKey, "DB.Port")
+		assert.Equal(t, e.Option, "db-port")
+		assert.Equal(t, e.OptArg, "x")
+	default:
+		assert.Fail(t, err.Error())
+	}
+}
+
+func TestParseFor_nestedStructWithDefaultPrefix(t *testing.T) {
+	type DB struct {
+		Host string
+		Port int `optcfg:"port"`
+	}
+	type Cache struct {
+		Host string
+	}
+	type MyOptions struct {
+		DB    DB
+		Cache Cache `optprefix:""`
+	}
+	options := MyOptions{}
+
+	cmd := cliargs.NewCmdFromArgs("app", []string{
+		"--DB-Host=db.example.com", "--DB-port", "5432", "--Host", "cache.example.com"})
+	err := cmd.ParseFor(&options)
+
+	assert.Nil(t, err)
+	assert.Equal(t, options.DB.Host, "db.example.com")
+	assert.Equal(t, options.DB.Port, 5432)
+	assert.Equal(t, options.Cache.Host, "cache.example.com")
+
+	assert.Equal(t, cmd.OptCfgs[0].StoreKey, "DB.Host")
+	assert.Equal(t, cmd.OptCfgs[0].Names, []string{"DB-Host"})
+	assert.Equal(t, cmd.OptCfgs[1].Names, []string{"DB-port"})
+	assert.Equal(t, cmd.OptCfgs[2].StoreKey, "Cache.Host")
+	assert.Equal(t, cmd.OptCfgs[2].Names, []string{"Host"})
+	assert.Equal(t, cmd.OptArg("DB.Host"), "db.example.com")
+	assert.Equal(t, cmd.OptArg("Cache.Host"), "cache.example.com")
+}
+
+func TestParseFor_nestedStructWithRequiresAndExclusiveGroup(t *testing.T) {
+	type Sub struct {
+		Host string `optcfg:"host"`
+		User string `optcfg:"user" optrequires:"host"`
+		JSON bool   `optcfg:"json" optexcl:"format"`
+		YAML bool   `optcfg:"yaml" optexcl:"format"`
+	}
+	type MyOptions struct {
+		A Sub `optprefix:"a-"`
+		B Sub `optprefix:"b-"`
+	}
+	options := MyOptions{}
+
+	cmd := cliargs.NewCmdFromArgs("app", []string{
+		"--a-host", "h", "--a-user", "u", "--a-json", "--b-yaml"})
+	err := cmd.ParseFor(&options)
+
+	assert.Nil(t, err)
+	assert.Equal(t, options.A.User, "u")
+	assert.True(t, options.A.JSON)
+	assert.True(t, options.B.YAML)
+	assert.Equal(t, cmd.OptCfgs[1].Requires, []string{"a-host"})
+	assert.Equal(t, cmd.OptCfgs[2].ExclusiveGroup, "a-format")
+	assert.Equal(t, cmd.OptCfgs[6].ExclusiveGroup, "b-format")
+
+	options = MyOptions{}
+	cmd = cliargs.NewCmdFromArgs("app", []string{"--b-user", "u", "--b-json", "--b-yaml"})
+	cmd.SetCollectsAllErrs(true)
+	err = cmd.ParseFor(&options)
+
+	switch e := err.(type) {
+	case errs.ErrorList:
+		assert.Equal(t, len(e.Errs), 2)
+		assert.Equal(t, e.Errs[0].(errs.OptionsAreExclusive).Group, "b-format")
+		assert.Equal(t, e.Errs[0].(errs.OptionsAreExclusive).Options, []string{"b-json", "b-yaml"})
+		assert.Equal(t, e.Errs[1].(errs.OptionRequiresOthers).Option, "b-user")
+		assert.Equal(t, e.Errs[1].(errs.OptionRequiresOthers).Missing, []string{"b-host"})
+	default:
+		assert.Fail(t, err.Error())
+	}
+}
+
+func TestParseFor_nestedStructRequiresReferences(t *testing.T) {
+	type DB struct {
+		Host     string `optcfg:"host" optrequires:"port"`
+		Port     int    `optcfg:"port"`
+		User     string `optcfg:"user" optrequires:"Password"`
+		Password string `optcfg:"password"`
+		Name     string `optcfg:"name" optrequires:"verbose"`
+	}
+	type MyOptions struct {
+		Verbose bool `optcfg:"verbose"`
+		DB      DB   `optprefix:"db-"`
+	}
+	options := MyOptions{}
+
+	cmd := cliargs.NewCmdFromArgs("app", []string{
+		"--verbose", "--db-host", "h", "--db-port", "1", "--db-user", "u", "--db-password", "p",
+		"--db-name", "n"})
+	err := cmd.ParseFor(&options)
+
+	assert.Nil(t, err)
+	assert.Equal(t, cmd.OptCfgs[1].Requires, []string{"db-port"})
+	assert.Equal(t, cmd.OptCfgs[3].Requires, []string{"DB.Password"})
+	assert.Equal(t, cmd.OptCfgs[5].Requires, []string{"verbose"})
+
+	options = MyOptions{}
+	cmd = cliargs.NewCmdFromArgs("app", []string{"--db-host", "h", "--db-user", "u", "--db-name", "n"})
+	cmd.SetCollectsAllErrs(true)
+	err = cmd.ParseFor(&options)
+
+	switch e := err.(type) {
+	case errs.ErrorList:
+		assert.Equal(t, len(e.Errs), 3)
+		assert.Equal(t, e.Errs[0].(errs.OptionRequiresOthers).Missing, []string{"db-port"})
+		assert.Equal(t, e.Errs[1].(errs.OptionRequiresOthers).Missing, []string{"DB.Password"})
+		assert.Equal(t, e.Errs[2].(errs.OptionRequiresOthers).Missing, []string{"verbose"})
+	default:
+		assert.Fail(t, err.Error())
+	}
+}
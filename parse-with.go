@@ -5,6 +5,12 @@
 package cliargs
 
 import (
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
 	"github.com/sttk/cliargs/errors"
 )
 
@@ -17,9 +23,19 @@ const anyOption = "*"
 // Options are separated to long format options and short format options.
 // About long/short format options, since they are same with Parse method, see the comment of
 // that method.
+// In addition, a short option of which option configuration has HasArg can take an option argument
+// attached directly after its name, like -ofoo or -O2.
+// In case of combined short options, the characters following such an option are its option
+// argument. (For example, -abfoo is equal to -a -b=foo if only b has HasArg.)
+// A short option of which name is a digit, like -9, is allowed only if such a name is configured.
+// Once any digit name is configured, all digits are parsed as short option names in the command, so
+// a negative number given as a command argument, like -5, causes UnconfiguredOption error instead
+// of OptionContainsInvalidChar error, and needs to be placed after the end-of-options delimiter --.
+// An argument following an option of which option configuration has HasArg is always taken as its
+// option argument, even if it looks like an option, like --offset -3.
 //
 // This method allows only options declared in option configurations, basically.
-// An option configuration has fields: StoreKey, Names, HasArg, IsArray, Defaults, Desc,
+// An option configuration has fields: StoreKey, Names, HasArg, IsArray, Defaults, EnvVars, Desc,
 // ArgInHelp, and Validator.
 //
 // When an option matches one of the Names in the option configurations, the option is registered
@@ -27,14 +43,57 @@ const anyOption = "*"
 // If both HasArg and IsArray are true, the option can have one or multiple option arguments, and
 // if HasArg is true and IsArray is false, the option can have only one option argument, otherwise
 // the option cannot have option arguments.
-// If Defaults field is specified and no option value is given in command line arguments, the value
-// of Defaults is set as the option arguments.
+// If both HasArg and IsMap are true, the option can have one or multiple option arguments of the
+// form of key=value, otherwise this method returns OptionArgIsInvalid error.
+// And if RejectsDupKey is true too, a key given multiple times causes MapKeyIsDuplicated error.
+// If EnvVars field is specified and no option value is given in command line arguments, the value
+// of the first environment variable which is set is used as the option arguments, with the same
+// validation as command line arguments.
+// An invalid value of an environment variable causes EnvVarIsInvalid error, and an invalid value
+// in configuration files causes ConfigValueIsInvalid error, which wraps the error of the validation.
+// If an option configuration of which IsConfigFile is true is given, the values in the
+// configuration files specified by that option are used as the option arguments of the options
+// of which StoreKey matches the keys in the files, when no option value is given in command line
+// arguments nor environment variables.
+// If Defaults field is specified and no option value is given in command line arguments,
+// environment variables, nor configuration files, the value of Defaults is set as the option
+// arguments.
+// That is, the precedence order is: command line > environment variables > configuration files >
+// Defaults.
+// If Required field is true and the option is not given by any of them, this method returns
+// MissingRequiredOption error which lists the names of all such options.
+//
+// Options of which option configurations have the same ExclusiveGroup cannot be given together,
+// otherwise this method returns OptionsAreExclusive error.
+// If any option in such a group is Required, one of the group must be given, otherwise this method
+// returns OptionGroupIsMissing error.
+// And if an option of which option configuration has Requires is given, the options named in
+// Requires must be present too, otherwise this method returns OptionRequiresOthers error.
+// These constraints are checked after Defaults are applied, but an option which is filled only
+// by Defaults does not conflict with others nor requires others.
+//
+// If an option of which option configuration has IsHelp or IsVersion is given in command line
+// arguments, this method returns HelpIsRequested or VersionIsRequested error without any other
+// errors, including the errors of required options, validators, and command arguments, so that the
+// caller can print the help or the version and exit normally.
+// These errors can be found with errors.As even if Cmd#SetCollectsAllErrs is set.
 //
 // If options not declared in option configurations are given in command line arguments, this
 // method basically returns UnconfiguradOption error.
+// This error has the configured option names similar to the given option as its suggestions, which
+// are found by Suggest function and retrieved with its Suggestions method.
 // However, if you want to allow other options, add an option configuration of which StoreKey or
 // the first element of Names is "*".
 //
+// If argument configurations are set to the field: Cmd#ArgCfgs before calling this method, the
+// command arguments are checked with them.
+// If fewer command arguments are given than required, this method returns MissingCmdArg error, and
+// if more command arguments are given than they can take, this method returns TooManyCmdArgs error.
+// If a command argument is invalidated by the Validator of its argument configuration, this method
+// returns CmdArgIsInvalid error.
+// The command arguments assigned to each argument configuration can be retrieved with Cmd#CmdArg
+// or Cmd#CmdArgs.
+//
 // The option configurations used to parsing are set into this Cmd instance, and it can be
 // retrieved from its field: Cmd#OptCfgs.
 func (cmd *Cmd) ParseWith(optCfgs []OptCfg) error {
@@ -67,9 +126,18 @@ func (cmd *Cmd) parseArgsWith(
 	optCfgs []OptCfg,
 	untilFirstArg bool,
 ) (int, bool, error) {
+	idx, isAfterEndOpt, errs := cmd.parseArgsWithCfgs(optCfgs, untilFirstArg)
+	return idx, isAfterEndOpt, cmd.toErr(errs)
+}
+
+func (cmd *Cmd) parseArgsWithCfgs(
+	optCfgs []OptCfg,
+	untilFirstArg bool,
+) (int, bool, []error) {
 
 	const ANY_OPT = "*"
 	hasAnyOpt := false
+	hasDigitOpt := false
 
 	var EMPTY_STRUCT struct{}
 
@@ -109,18 +177,22 @@ func (cmd *Cmd) parseArgsWith(
 		_, exists := optMap[storeKey]
 		if exists {
 			e := errors.StoreKeyIsDuplicated{StoreKey: storeKey, Name: firstName}
-			return -1, cmd.isAfterEndOpt, e
+			return -1, cmd.isAfterEndOpt, []error{e}
 		}
 		optMap[storeKey] = EMPTY_STRUCT
 
 		if !cfg.HasArg {
 			if cfg.IsArray {
 				e := errors.ConfigIsArrayButHasNoArg{StoreKey: storeKey, Name: firstName}
-				return -1, cmd.isAfterEndOpt, e
+				return -1, cmd.isAfterEndOpt, []error{e}
+			}
+			if cfg.IsMap {
+				e := errors.ConfigIsMapButHasNoArg{StoreKey: storeKey, Name: firstName}
+				return -1, cmd.isAfterEndOpt, []error{e}
 			}
 			if cfg.Defaults != nil {
 				e := errors.ConfigHasDefaultsButHasNoArg{StoreKey: storeKey, Name: firstName}
-				return -1, cmd.isAfterEndOpt, e
+				return -1, cmd.isAfterEndOpt, []error{e}
 			}
 		}
 
@@ -131,13 +203,20 @@ func (cmd *Cmd) parseArgsWith(
 				_, exists := cfgMap[nm]
 				if exists {
 					e := errors.OptionNameIsDuplicated{StoreKey: storeKey, Name: nm}
-					return -1, cmd.isAfterEndOpt, e
+					return -1, cmd.isAfterEndOpt, []error{e}
 				}
 				cfgMap[nm] = i
 			}
 		}
 	}
 
+	for nm := range cfgMap {
+		if isDigitOptName(nm) {
+			hasDigitOpt = true
+			break
+		}
+	}
+
 	var takeOptArgs = func(opt string) bool {
 		i, exists := cfgMap[opt]
 		if exists {
@@ -176,29 +255,27 @@ func (cmd *Cmd) parseArgsWith(
 				}
 
 				arr, _ := cmd.opts[storeKey]
-				if len(arr) > 0 {
-					if !cfg.IsArray {
-						return errors.OptionIsNotArray{
-							StoreKey: storeKey,
-							Option:   name,
-						}
+				if len(arr) > 0 && !cfg.IsArray && !cfg.IsMap {
+					return errors.OptionIsNotArray{
+						StoreKey: storeKey,
+						Option:   name,
 					}
-					if cfg.Validator != nil {
-						err := (*cfg.Validator)(storeKey, name, a[0])
-						if err != nil {
-							return err
-						}
+				}
+				val := a[0]
+				if cfg.IsMap {
+					v, err := checkMapOptArg(cfg, storeKey, name, a[0], arr)
+					if err != nil {
+						return err
 					}
-					cmd.opts[storeKey] = append(arr, a[0])
-				} else {
-					if cfg.Validator != nil {
-						err := (*cfg.Validator)(storeKey, name, a[0])
-						if err != nil {
-							return err
-						}
+					val = v
+				}
+				if cfg.Validator != nil {
+					err := (*cfg.Validator)(storeKey, name, val)
+					if err != nil {
+						return err
 					}
-					cmd.opts[storeKey] = append(arr, a[0])
 				}
+				cmd.opts[storeKey] = append(arr, a[0])
 			} else {
 				if cfg.HasArg {
 					return errors.OptionNeedsArg{
@@ -216,9 +293,7 @@ func (cmd *Cmd) parseArgsWith(
 			return nil
 		} else {
 			if !hasAnyOpt {
-				return errors.UnconfiguredOption{
-					Option: name,
-				}
+				return errors.NewUnconfiguredOption(name, Suggest(name, optNamesOf(optCfgs)))
 			}
 
 			if len(a) > 0 {
@@ -231,48 +306,458 @@ func (cmd *Cmd) parseArgsWith(
 		}
 	}
 
-	idx, isAfterEndOpt, err := parseArgs(
+	idx, isAfterEndOpt, errs := parseArgs(
 		cmd._args,
 		collectArgs,
 		collectOpts,
 		takeOptArgs,
+		hasDigitOpt,
 		untilFirstArg,
 		cmd.isAfterEndOpt,
 	)
 
+	if e := cmd.checkHelpAndVersion(optCfgs); e != nil {
+		return idx, isAfterEndOpt, []error{e}
+	}
+
+	cfgFileArgs := make(map[string][]string)
+	isDefaulted := make(map[string]bool)
+
 	for _, cfg := range optCfgs {
-		storeKey := cfg.StoreKey
-		if len(storeKey) == 0 && len(cfg.Names) > 0 {
-			for _, nm := range cfg.Names {
-				if len(nm) > 0 {
-					storeKey = nm
-					break
+		if !cfg.IsConfigFile || !cfg.HasArg {
+			continue
+		}
+
+		storeKey := storeKeyOf(cfg)
+		if len(storeKey) == 0 || storeKey == ANY_OPT {
+			continue
+		}
+
+		isDefault, e := cmd.fillOptArgs(cfg, storeKey, nil)
+		if e != nil {
+			errs = append(errs, e)
+			continue
+		}
+		isDefaulted[storeKey] = isDefault
+
+		for _, path := range cmd.opts[storeKey] {
+			data, e := os.ReadFile(path)
+			if e != nil {
+				if isDefault && os.IsNotExist(e) {
+					continue
 				}
+				errs = append(errs, errors.ConfigFileIsNotReadable{File: path, Cause: e})
+				continue
+			}
+			m, e := parseConfigFile(path, data)
+			if e != nil {
+				errs = append(errs, e)
+				continue
+			}
+			for k, v := range m {
+				cfgFileArgs[k] = v
 			}
 		}
+	}
 
-		if len(storeKey) == 0 {
+	for _, cfg := range optCfgs {
+		storeKey := storeKeyOf(cfg)
+		if len(storeKey) == 0 || storeKey == ANY_OPT {
 			continue
 		}
 
-		if storeKey == ANY_OPT {
+		if !cfg.IsConfigFile || !cfg.HasArg {
+			isDefault, e := cmd.fillOptArgs(cfg, storeKey, cfgFileArgs)
+			if e != nil {
+				errs = append(errs, e)
+				continue
+			}
+			isDefaulted[storeKey] = isDefault
+		}
+
+		arr, exists := cmd.opts[storeKey]
+		if exists && cfg.onParsed != nil {
+			e := (*cfg.onParsed)(arr)
+			if e != nil {
+				errs = append(errs, e)
+			}
+		}
+	}
+
+	var missingOpts []string
+	for _, cfg := range optCfgs {
+		storeKey := storeKeyOf(cfg)
+		if !cfg.Required || len(cfg.ExclusiveGroup) > 0 || len(storeKey) == 0 || storeKey == ANY_OPT {
 			continue
 		}
+		if _, exists := cmd.opts[storeKey]; !exists {
+			missingOpts = append(missingOpts, firstNameOf(cfg))
+		}
+	}
+	if len(missingOpts) > 0 {
+		errs = append(errs, errors.MissingRequiredOption{Options: missingOpts})
+	}
 
+	errs = append(errs, cmd.checkExclusiveGroups(optCfgs, isDefaulted)...)
+	errs = append(errs, cmd.checkRequiredOthers(optCfgs, cfgMap, isDefaulted)...)
+
+	if !untilFirstArg {
+		errs = append(errs, cmd.checkCmdArgs()...)
+	}
+
+	return idx, isAfterEndOpt, errs
+}
+
+// checkHelpAndVersion is the method that returns HelpIsRequested or VersionIsRequested error if an
+// option of which option configuration has IsHelp or IsVersion is given in command line arguments.
+// If both are given, HelpIsRequested takes precedence.
+func (cmd *Cmd) checkHelpAndVersion(optCfgs []OptCfg) error {
+	var versionCfg *OptCfg
+	for i, cfg := range optCfgs {
+		if !cfg.IsHelp && !cfg.IsVersion {
+			continue
+		}
+		storeKey := storeKeyOf(cfg)
 		arr, exists := cmd.opts[storeKey]
-		if !exists && cfg.Defaults != nil {
-			arr = cfg.Defaults
+		if len(storeKey) == 0 || !exists {
+			continue
+		}
+		if cfg.IsHelp {
+			if cfg.onParsed != nil {
+				(*cfg.onParsed)(arr)
+			}
+			return errors.HelpIsRequested{Option: firstNameOf(cfg)}
+		}
+		if versionCfg == nil {
+			versionCfg = &optCfgs[i]
+		}
+	}
+	if versionCfg != nil {
+		if versionCfg.onParsed != nil {
+			(*versionCfg.onParsed)(cmd.opts[storeKeyOf(*versionCfg)])
+		}
+		return errors.VersionIsRequested{Option: firstNameOf(*versionCfg)}
+	}
+	return nil
+}
+
+func (cmd *Cmd) checkExclusiveGroups(optCfgs []OptCfg, isDefaulted map[string]bool) []error {
+	var groups []string
+	members := make(map[string][]OptCfg)
+	for _, cfg := range optCfgs {
+		storeKey := storeKeyOf(cfg)
+		if len(cfg.ExclusiveGroup) == 0 || len(storeKey) == 0 || storeKey == anyOption {
+			continue
+		}
+		if _, exists := members[cfg.ExclusiveGroup]; !exists {
+			groups = append(groups, cfg.ExclusiveGroup)
+		}
+		members[cfg.ExclusiveGroup] = append(members[cfg.ExclusiveGroup], cfg)
+	}
+
+	var errs []error
+	for _, group := range groups {
+		var given, present, all []string
+		isRequired := false
+		for _, cfg := range members[group] {
+			storeKey := storeKeyOf(cfg)
+			all = append(all, firstNameOf(cfg))
+			if _, exists := cmd.opts[storeKey]; exists {
+				present = append(present, firstNameOf(cfg))
+				if !isDefaulted[storeKey] {
+					given = append(given, firstNameOf(cfg))
+				}
+			}
+			isRequired = isRequired || cfg.Required
+		}
+
+		if len(given) > 1 {
+			errs = append(errs, errors.OptionsAreExclusive{Group: group, Options: given})
+		} else if isRequired && len(present) == 0 {
+			errs = append(errs, errors.OptionGroupIsMissing{Group: group, Options: all})
+		}
+	}
+	return errs
+}
+
+func (cmd *Cmd) checkRequiredOthers(
+	optCfgs []OptCfg, cfgMap map[string]int, isDefaulted map[string]bool,
+) []error {
+	var errs []error
+	for _, cfg := range optCfgs {
+		storeKey := storeKeyOf(cfg)
+		if len(cfg.Requires) == 0 || len(storeKey) == 0 || storeKey == anyOption {
+			continue
+		}
+		if _, exists := cmd.opts[storeKey]; !exists || isDefaulted[storeKey] {
+			continue
+		}
+
+		var missing []string
+		for _, name := range cfg.Requires {
+			otherKey := name
+			if i, exists := cfgMap[name]; exists {
+				otherKey = storeKeyOf(optCfgs[i])
+			}
+			if _, exists := cmd.opts[otherKey]; !exists {
+				missing = append(missing, name)
+			}
+		}
+
+		if len(missing) > 0 {
+			errs = append(errs, errors.OptionRequiresOthers{
+				Option:   firstNameOf(cfg),
+				StoreKey: storeKey,
+				Missing:  missing,
+			})
+		}
+	}
+	return errs
+}
+
+// isDigitOptName is the function that checks whether the option name is a short option name which
+// is a digit, like "9" of -9.
+// Short options of which names are digits are allowed only when such a name is configured.
+func isDigitOptName(name string) bool {
+	return len(name) == 1 && '0' <= name[0] && name[0] <= '9'
+}
+
+// optNamesOf is the function that returns the names of all options in the option configurations,
+// in the order of the configurations.
+// For an option configuration which has no name, its store key is used instead.
+func optNamesOf(optCfgs []OptCfg) []string {
+	var names []string
+	for _, cfg := range optCfgs {
+		n := len(names)
+		for _, nm := range cfg.Names {
+			if len(nm) > 0 && nm != anyOption {
+				names = append(names, nm)
+			}
+		}
+		if len(names) == n && len(cfg.StoreKey) > 0 && cfg.StoreKey != anyOption {
+			names = append(names, cfg.StoreKey)
+		}
+	}
+	return names
+}
+
+// firstNameOf is the function that returns the first non-empty name of the option configuration,
+// or its store key if it has no name.
+func firstNameOf(cfg OptCfg) string {
+	for _, nm := range cfg.Names {
+		if len(nm) > 0 {
+			return nm
+		}
+	}
+	return cfg.StoreKey
+}
+
+func storeKeyOf(cfg OptCfg) string {
+	if len(cfg.StoreKey) > 0 {
+		return cfg.StoreKey
+	}
+	for _, nm := range cfg.Names {
+		if len(nm) > 0 {
+			return nm
+		}
+	}
+	return ""
+}
+
+// fillOptArgs is the method that sets the option argument(s) of the option which is not given in
+// command line arguments.
+// The option arguments are taken from environment variables, configuration files, and Defaults in
+// this order.
+// The returned flag indicates whether the option arguments are taken from Defaults.
+func (cmd *Cmd) fillOptArgs(
+	cfg OptCfg, storeKey string, cfgFileArgs map[string][]string,
+) (bool, error) {
+	_, exists := cmd.opts[storeKey]
+	if exists {
+		return false, nil
+	}
+
+	if len(cfg.EnvVars) > 0 {
+		arr, found, e := optArgsFromEnvVars(cfg, storeKey)
+		if e != nil {
+			return false, e
+		}
+		if found {
 			cmd.opts[storeKey] = arr
-			exists = true
+			return false, nil
 		}
+	}
 
-		if exists && cfg.onParsed != nil {
-			e := (*cfg.onParsed)(arr)
-			if e != nil && err == nil {
-				err = e
+	vals, exists := cfgFileArgs[storeKey]
+	if !exists && cfg.IsMap {
+		vals, exists = mapArgsFromConfigFile(storeKey, cfgFileArgs)
+	}
+	if exists {
+		arr, found, e := optArgsFromConfigFile(cfg, storeKey, vals)
+		if e != nil {
+			return false, e
+		}
+		if found {
+			cmd.opts[storeKey] = arr
+			return false, nil
+		}
+	}
+
+	if cfg.Defaults != nil {
+		cmd.opts[storeKey] = cfg.Defaults
+		return true, nil
+	}
+
+	return false, nil
+}
+
+func optArgsFromEnvVars(cfg OptCfg, storeKey string) ([]string, bool, error) {
+	option := firstNameOf(cfg)
+
+	for _, env := range cfg.EnvVars {
+		val, _ := os.LookupEnv(env)
+		if len(val) == 0 {
+			continue
+		}
+
+		if !cfg.HasArg {
+			b, e := strconv.ParseBool(val)
+			if e != nil {
+				return nil, false, errors.EnvVarIsInvalid{
+					EnvVar: env, Option: option, StoreKey: storeKey, Value: val,
+					Cause: errors.OptionArgIsInvalid{
+						StoreKey: storeKey, Option: option, OptArg: val, TypeKind: reflect.Bool, Cause: e}}
 			}
+			return nil, b, nil
+		}
+
+		var arr []string
+		if cfg.IsArray || cfg.IsMap {
+			arr = strings.Split(val, ",")
+		} else {
+			arr = []string{val}
 		}
+
+		e := validateOptArgs(cfg, storeKey, arr)
+		if e != nil {
+			return nil, false, errors.EnvVarIsInvalid{
+				EnvVar: env, Option: option, StoreKey: storeKey, Value: val, Cause: e}
+		}
+
+		return arr, true, nil
 	}
 
-	return idx, isAfterEndOpt, err
+	return nil, false, nil
+}
+
+func optArgsFromConfigFile(cfg OptCfg, storeKey string, vals []string) ([]string, bool, error) {
+	option := firstNameOf(cfg)
+
+	var newErr = func(cause error) error {
+		return errors.ConfigValueIsInvalid{
+			Key: storeKey, Option: option, StoreKey: storeKey, Value: strings.Join(vals, ","),
+			Cause: cause}
+	}
+
+	if !cfg.HasArg {
+		if len(vals) != 1 {
+			return nil, false, newErr(errors.OptionIsNotArray{Option: option, StoreKey: storeKey})
+		}
+		b, e := strconv.ParseBool(vals[0])
+		if e != nil {
+			return nil, false, newErr(errors.OptionArgIsInvalid{
+				StoreKey: storeKey, Option: option, OptArg: vals[0], TypeKind: reflect.Bool, Cause: e})
+		}
+		return nil, b, nil
+	}
+
+	if !cfg.IsArray && !cfg.IsMap {
+		if len(vals) == 0 {
+			return nil, false, nil
+		}
+		if len(vals) > 1 {
+			return nil, false, newErr(errors.OptionIsNotArray{Option: option, StoreKey: storeKey})
+		}
+	}
+
+	e := validateOptArgs(cfg, storeKey, vals)
+	if e != nil {
+		return nil, false, newErr(e)
+	}
+
+	return vals, true, nil
+}
+
+// mapArgsFromConfigFile is the function that makes the option arguments of a map option from the
+// values in configuration files of which keys are the store key followed by a dot and a map key,
+// like Label.env.
+// The option arguments are sorted by the map keys.
+func mapArgsFromConfigFile(storeKey string, cfgFileArgs map[string][]string) ([]string, bool) {
+	prefix := storeKey + "."
+	var keys []string
+	for k := range cfgFileArgs {
+		if strings.HasPrefix(k, prefix) && len(k) > len(prefix) {
+			keys = append(keys, k)
+		}
+	}
+	if len(keys) == 0 {
+		return nil, false
+	}
+	sort.Strings(keys)
+
+	var arr []string
+	for _, k := range keys {
+		for _, v := range cfgFileArgs[k] {
+			arr = append(arr, k[len(prefix):]+"="+v)
+		}
+	}
+	return arr, true
+}
+
+// validateOptArgs is the function that checks the option arguments which are taken from other
+// than command line arguments with the option configuration.
+// The errors are reported with the first name of the option, and are wrapped by the callers with
+// the errors which indicate the sources of the option arguments.
+func validateOptArgs(cfg OptCfg, storeKey string, arr []string) error {
+	option := firstNameOf(cfg)
+	for i, a := range arr {
+		val := a
+		if cfg.IsMap {
+			v, e := checkMapOptArg(cfg, storeKey, option, a, arr[:i])
+			if e != nil {
+				return e
+			}
+			val = v
+		}
+		if cfg.Validator != nil {
+			e := (*cfg.Validator)(storeKey, option, val)
+			if e != nil {
+				return e
+			}
+		}
+	}
+	return nil
+}
+
+// checkMapOptArg is the function that checks that an option argument of a map option has the form
+// of key=value, and that its key is not in the preceding option arguments if the option
+// configuration rejects duplicated keys.
+// This function returns the value part of the option argument.
+func checkMapOptArg(
+	cfg OptCfg, storeKey string, option string, arg string, preceding []string,
+) (string, error) {
+	key, val, found := strings.Cut(arg, "=")
+	if !found {
+		return "", errors.OptionArgIsInvalid{
+			StoreKey: storeKey, Option: option, OptArg: arg, TypeKind: reflect.Map,
+			Cause: errors.OptArgIsNotKeyValue{OptArg: arg}}
+	}
+	if cfg.RejectsDupKey {
+		for _, a := range preceding {
+			if k, _, _ := strings.Cut(a, "="); k == key {
+				return "", errors.MapKeyIsDuplicated{StoreKey: storeKey, Option: option, Key: key}
+			}
+		}
+	}
+	return val, nil
 }
@@ -1,7 +1,10 @@
 package cliargs_test
 
 import (
+	goerrors "errors"
+	"io/fs"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -224,10 +227,11 @@ func TestParseWith_oneCfgAndOneDifferentLongOpt(t *testing.T) {
 	err := cmd.ParseWith(optCfgs)
 
 	assert.NotNil(t, err)
-	assert.Equal(t, err.Error(), "UnconfiguredOption{Option:boo-far}")
+	assert.Equal(t, err.Error(), "UnconfiguredOption{Option:boo-far,Suggestions:[foo-bar]}")
 	switch e := err.(type) {
 	case errors.UnconfiguredOption:
 		assert.Equal(t, e.Option, "boo-far")
+		assert.Equal(t, e.Suggestions(), []string{"foo-bar"})
 	default:
 		assert.Fail(t, err.Error())
 	}
@@ -1903,3 +1907,999 @@ func TestParseUntilSubCmd_parseAfterEndOptMark(t *testing.T) {
 	assert.Equal(t, subCmd.OptArg("bar"), "")
 	assert.Equal(t, subCmd.OptArgs("bar"), []string(nil))
 }
+
+func TestParseWith_withCmdFromArgs(t *testing.T) {
+	optCfgs := []cliargs.OptCfg{
+		cliargs.OptCfg{Names: []string{"foo-bar", "f"}},
+		cliargs.OptCfg{Names: []string{"baz"}, HasArg: true},
+	}
+
+	cmd := cliargs.NewCmdFromArgs("app", []string{"-f", "qux", "--baz", "1"})
+	err := cmd.ParseWith(optCfgs)
+
+	assert.Nil(t, err)
+	assert.Equal(t, cmd.Name, "app")
+	assert.Equal(t, cmd.Args, []string{"qux"})
+	assert.True(t, cmd.HasOpt("foo-bar"))
+	assert.Equal(t, cmd.OptArg("baz"), "1")
+
+	cmd.ResetArgs([]string{"--baz=2"})
+	err = cmd.ParseWith(optCfgs)
+
+	assert.Nil(t, err)
+	assert.Equal(t, cmd.Args, []string{})
+	assert.False(t, cmd.HasOpt("foo-bar"))
+	assert.Equal(t, cmd.OptArgs("baz"), []string{"2"})
+}
+
+func TestParseUntilSubCmdWith_withCmdFromArgs(t *testing.T) {
+	optCfgs := []cliargs.OptCfg{
+		cliargs.OptCfg{Names: []string{"foo-bar", "f"}},
+	}
+
+	cmd := cliargs.NewCmdFromArgs("app", []string{"-f", "qux", "--baz", "1"})
+	subCmd, err := cmd.ParseUntilSubCmdWith(optCfgs)
+
+	assert.Nil(t, err)
+	assert.True(t, cmd.HasOpt("foo-bar"))
+	assert.Equal(t, subCmd.Name, "qux")
+
+	err = subCmd.ParseWith([]cliargs.OptCfg{
+		cliargs.OptCfg{Names: []string{"baz"}, HasArg: true},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, subCmd.OptArg("baz"), "1")
+}
+
+func TestParseWith_collectAllErrs(t *testing.T) {
+	optCfgs := []cliargs.OptCfg{
+		cliargs.OptCfg{Names: []string{"foo"}},
+		cliargs.OptCfg{
+			Names:     []string{"bar"},
+			HasArg:    true,
+			Validator: &validators.ValidateInt,
+		},
+		cliargs.OptCfg{Names: []string{"baz"}, HasArg: true},
+	}
+
+	cmd := cliargs.NewCmdFromArgs("app", []string{
+		"--foo=1", "--bar", "x", "--qux", "--baz", "--bar=2", "quux",
+	})
+	cmd.SetCollectsAllErrs(true)
+	err := cmd.ParseWith(optCfgs)
+
+	switch e := err.(type) {
+	case errors.ErrorList:
+		assert.Equal(t, len(e.Errs), 3)
+		assert.Equal(t, e.Errs[0], errors.OptionTakesNoArg{Option: "foo", StoreKey: "foo"})
+		assert.Equal(t, e.Errs[1].(errors.OptionArgIsInvalid).Option, "bar")
+		assert.Equal(t, e.Errs[1].(errors.OptionArgIsInvalid).OptArg, "x")
+		assert.Equal(t, e.Errs[2], errors.UnconfiguredOption{Option: "qux"})
+	default:
+		assert.Fail(t, err.Error())
+	}
+
+	var e0 errors.UnconfiguredOption
+	assert.True(t, goerrors.As(err, &e0))
+	assert.Equal(t, e0.Option, "qux")
+	assert.True(t, goerrors.Is(err, errors.UnconfiguredOption{Option: "qux"}))
+
+	assert.Equal(t, cmd.Args, []string{"quux"})
+	assert.Equal(t, cmd.OptArgs("baz"), []string{"--bar=2"})
+}
+
+func TestParseWith_collectAllErrsButConfigErr(t *testing.T) {
+	optCfgs := []cliargs.OptCfg{
+		cliargs.OptCfg{Names: []string{"foo"}, IsArray: true},
+	}
+
+	cmd := cliargs.NewCmdFromArgs("app", []string{"--foo", "--bar"})
+	cmd.SetCollectsAllErrs(true)
+	err := cmd.ParseWith(optCfgs)
+
+	assert.Equal(t, err, errors.ErrorList{Errs: []error{
+		errors.ConfigIsArrayButHasNoArg{StoreKey: "foo", Name: "foo"},
+	}})
+}
+
+func TestParseUntilSubCmdWith_collectAllErrs(t *testing.T) {
+	optCfgs := []cliargs.OptCfg{
+		cliargs.OptCfg{Names: []string{"foo"}},
+	}
+
+	cmd := cliargs.NewCmdFromArgs("app", []string{"--foo=1", "--bar", "qux", "--baz", "--qux"})
+	cmd.SetCollectsAllErrs(true)
+	subCmd, err := cmd.ParseUntilSubCmdWith(optCfgs)
+
+	assert.Equal(t, err, errors.ErrorList{Errs: []error{
+		errors.OptionTakesNoArg{Option: "foo", StoreKey: "foo"},
+		errors.UnconfiguredOption{Option: "bar"},
+	}})
+
+	err = subCmd.ParseWith([]cliargs.OptCfg{})
+	assert.Equal(t, err, errors.ErrorList{Errs: []error{
+		errors.UnconfiguredOption{Option: "baz"},
+		errors.UnconfiguredOption{Option: "qux"},
+	}})
+}
+
+func TestParseWith_envVarIsUsedIfOptionIsNotGiven(t *testing.T) {
+	t.Setenv("APP_FOO", "")
+	t.Setenv("APP_FOO2", "abc")
+
+	optCfgs := []cliargs.OptCfg{
+		cliargs.OptCfg{
+			Names:    []string{"foo"},
+			HasArg:   true,
+			EnvVars:  []string{"APP_FOO0", "APP_FOO", "APP_FOO2"},
+			Defaults: []string{"def"},
+		},
+	}
+
+	cmd := cliargs.NewCmdFromArgs("app", []string{})
+	err := cmd.ParseWith(optCfgs)
+	assert.Nil(t, err)
+	assert.Equal(t, cmd.OptArgs("foo"), []string{"abc"})
+
+	cmd = cliargs.NewCmdFromArgs("app", []string{"--foo", "xyz"})
+	err = cmd.ParseWith(optCfgs)
+	assert.Nil(t, err)
+	assert.Equal(t, cmd.OptArgs("foo"), []string{"xyz"})
+}
+
+func TestParseWith_defaultsAreUsedIfEnvVarIsNotSet(t *testing.T) {
+	t.Setenv("APP_FOO", "")
+
+	optCfgs := []cliargs.OptCfg{
+		cliargs.OptCfg{
+			Names:    []string{"foo"},
+			HasArg:   true,
+			EnvVars:  []string{"APP_FOO0", "APP_FOO"},
+			Defaults: []string{"def"},
+		},
+	}
+
+	cmd := cliargs.NewCmdFromArgs("app", []string{})
+	err := cmd.ParseWith(optCfgs)
+	assert.Nil(t, err)
+	assert.Equal(t, cmd.OptArgs("foo"), []string{"def"})
+}
+
+func TestParseWith_envVarIsSplitIfOptionIsArray(t *testing.T) {
+	t.Setenv("APP_FOO", "1,2,3")
+
+	optCfgs := []cliargs.OptCfg{
+		cliargs.OptCfg{
+			Names:     []string{"foo"},
+			HasArg:    true,
+			IsArray:   true,
+			EnvVars:   []string{"APP_FOO"},
+			Validator: &validators.ValidateInt,
+		},
+	}
+
+	cmd := cliargs.NewCmdFromArgs("app", []string{})
+	err := cmd.ParseWith(optCfgs)
+	assert.Nil(t, err)
+	assert.Equal(t, cmd.OptArgs("foo"), []string{"1", "2", "3"})
+}
+
+func TestParseWith_envVarIsInvalidatedByValidator(t *testing.T) {
+	t.Setenv("APP_FOO", "1,x,3")
+
+	optCfgs := []cliargs.OptCfg{
+		cliargs.OptCfg{
+			Names:     []string{"foo"},
+			HasArg:    true,
+			IsArray:   true,
+			EnvVars:   []string{"APP_FOO"},
+			Defaults:  []string{"9"},
+			Validator: &validators.ValidateInt,
+		},
+	}
+
+	cmd := cliargs.NewCmdFromArgs("app", []string{})
+	err := cmd.ParseWith(optCfgs)
+
+	switch e := err.(type) {
+	case errors.EnvVarIsInvalid:
+		assert.Equal(t, e.EnvVar, "APP_FOO")
+		assert.Equal(t, e.Option, "foo")
+		assert.Equal(t, e.StoreKey, "foo")
+		assert.Equal(t, e.Value, "1,x,3")
+		var ee errors.OptionArgIsInvalid
+		assert.True(t, goerrors.As(err, &ee))
+		assert.Equal(t, ee.Option, "foo")
+		assert.Equal(t, ee.OptArg, "x")
+		assert.Equal(t, errors.Message(err),
+			"invalid value '1,x,3' of environment variable 'APP_FOO' for option '--foo': "+
+				"strconv.ParseInt: parsing \"x\": invalid syntax")
+	default:
+		assert.Fail(t, err.Error())
+	}
+	assert.False(t, cmd.HasOpt("foo"))
+}
+
+func TestParseWith_envVarOfBoolOption(t *testing.T) {
+	optCfgs := []cliargs.OptCfg{
+		cliargs.OptCfg{
+			Names:   []string{"foo"},
+			EnvVars: []string{"APP_FOO"},
+		},
+	}
+
+	t.Setenv("APP_FOO", "true")
+	cmd := cliargs.NewCmdFromArgs("app", []string{})
+	err := cmd.ParseWith(optCfgs)
+	assert.Nil(t, err)
+	assert.True(t, cmd.HasOpt("foo"))
+	assert.Equal(t, cmd.OptArgs("foo"), []string(nil))
+
+	t.Setenv("APP_FOO", "0")
+	cmd = cliargs.NewCmdFromArgs("app", []string{})
+	err = cmd.ParseWith(optCfgs)
+	assert.Nil(t, err)
+	assert.False(t, cmd.HasOpt("foo"))
+
+	t.Setenv("APP_FOO", "yes")
+	cmd = cliargs.NewCmdFromArgs("app", []string{})
+	err = cmd.ParseWith(optCfgs)
+	switch e := err.(type) {
+	case errors.EnvVarIsInvalid:
+		assert.Equal(t, e.EnvVar, "APP_FOO")
+		assert.Equal(t, e.Option, "foo")
+		assert.Equal(t, e.Value, "yes")
+		assert.Equal(t, e.Cause.(errors.OptionArgIsInvalid).OptArg, "yes")
+	default:
+		assert.Fail(t, err.Error())
+	}
+	assert.False(t, cmd.HasOpt("foo"))
+}
+
+func TestParseWith_configFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.json")
+	os.WriteFile(path, []byte(`{"foo": "F", "bar": ["1", "2"], "baz": true, "qux": "Q"}`), 0644)
+
+	t.Setenv("APP_QUX", "E")
+
+	optCfgs := []cliargs.OptCfg{
+		cliargs.OptCfg{Names: []string{"config", "c"}, HasArg: true, IsConfigFile: true},
+		cliargs.OptCfg{Names: []string{"foo"}, HasArg: true, Defaults: []string{"D"}},
+		cliargs.OptCfg{Names: []string{"bar"}, HasArg: true, IsArray: true},
+		cliargs.OptCfg{Names: []string{"baz"}},
+		cliargs.OptCfg{Names: []string{"qux"}, HasArg: true, EnvVars: []string{"APP_QUX"}},
+		cliargs.OptCfg{Names: []string{"quux"}, HasArg: true, Defaults: []string{"D"}},
+	}
+
+	cmd := cliargs.NewCmdFromArgs("app", []string{"-c", path, "--bar", "3"})
+	err := cmd.ParseWith(optCfgs)
+
+	assert.Nil(t, err)
+	assert.Equal(t, cmd.OptArgs("config"), []string{path})
+	assert.Equal(t, cmd.OptArgs("foo"), []string{"F"})
+	assert.Equal(t, cmd.OptArgs("bar"), []string{"3"})
+	assert.True(t, cmd.HasOpt("baz"))
+	assert.Equal(t, cmd.OptArgs("qux"), []string{"E"})
+	assert.Equal(t, cmd.OptArgs("quux"), []string{"D"})
+}
+
+func TestParseWith_configFileFromEnvVarOrDefaults(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.ini")
+	os.WriteFile(path, []byte("foo = F\n"), 0644)
+
+	optCfgs := []cliargs.OptCfg{
+		cliargs.OptCfg{
+			Names:        []string{"config"},
+			HasArg:       true,
+			IsConfigFile: true,
+			EnvVars:      []string{"APP_CONFIG"},
+			Defaults:     []string{path},
+		},
+		cliargs.OptCfg{Names: []string{"foo"}, HasArg: true},
+	}
+
+	cmd := cliargs.NewCmdFromArgs("app", []string{})
+	err := cmd.ParseWith(optCfgs)
+	assert.Nil(t, err)
+	assert.Equal(t, cmd.OptArgs("foo"), []string{"F"})
+
+	path2 := filepath.Join(dir, "app.yaml")
+	os.WriteFile(path2, []byte("foo: G\n"), 0644)
+	t.Setenv("APP_CONFIG", path2)
+
+	cmd = cliargs.NewCmdFromArgs("app", []string{})
+	err = cmd.ParseWith(optCfgs)
+	assert.Nil(t, err)
+	assert.Equal(t, cmd.OptArgs("foo"), []string{"G"})
+}
+
+func TestParseWith_configFileOfDefaultsDoesNotExist(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.json")
+
+	optCfgs := []cliargs.OptCfg{
+		cliargs.OptCfg{
+			Names:        []string{"config"},
+			HasArg:       true,
+			IsConfigFile: true,
+			Defaults:     []string{path},
+		},
+		cliargs.OptCfg{Names: []string{"foo"}, HasArg: true},
+	}
+
+	cmd := cliargs.NewCmdFromArgs("app", []string{})
+	err := cmd.ParseWith(optCfgs)
+	assert.Nil(t, err)
+	assert.False(t, cmd.HasOpt("foo"))
+
+	cmd = cliargs.NewCmdFromArgs("app", []string{"--config", path})
+	err = cmd.ParseWith(optCfgs)
+	switch e := err.(type) {
+	case errors.ConfigFileIsNotReadable:
+		assert.Equal(t, e.File, path)
+		assert.True(t, goerrors.Is(err, fs.ErrNotExist))
+	default:
+		assert.Fail(t, err.Error())
+	}
+}
+
+func TestParseWith_multipleConfigFiles(t *testing.T) {
+	dir := t.TempDir()
+	path1 := filepath.Join(dir, "app1.json")
+	os.WriteFile(path1, []byte(`{"foo": "F1", "bar": "B1"}`), 0644)
+	path2 := filepath.Join(dir, "app2.json")
+	os.WriteFile(path2, []byte(`{"foo": "F2"}`), 0644)
+
+	optCfgs := []cliargs.OptCfg{
+		cliargs.OptCfg{Names: []string{"config"}, HasArg: true, IsArray: true, IsConfigFile: true},
+		cliargs.OptCfg{Names: []string{"foo"}, HasArg: true},
+		cliargs.OptCfg{Names: []string{"bar"}, HasArg: true},
+	}
+
+	cmd := cliargs.NewCmdFromArgs("app", []string{"--config", path1, "--config", path2})
+	err := cmd.ParseWith(optCfgs)
+	assert.Nil(t, err)
+	assert.Equal(t, cmd.OptArgs("foo"), []string{"F2"})
+	assert.Equal(t, cmd.OptArgs("bar"), []string{"B1"})
+}
+
+func TestParseWith_configFileHasInvalidValues(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.json")
+	os.WriteFile(path, []byte(`{"foo": ["1", "2"], "bar": "x", "baz": "yes"}`), 0644)
+
+	optCfgs := []cliargs.OptCfg{
+		cliargs.OptCfg{Names: []string{"config"}, HasArg: true, IsConfigFile: true},
+		cliargs.OptCfg{Names: []string{"foo"}, HasArg: true},
+		cliargs.OptCfg{Names: []string{"bar"}, HasArg: true, Validator: &validators.ValidateInt},
+		cliargs.OptCfg{Names: []string{"baz"}},
+	}
+
+	cmd := cliargs.NewCmdFromArgs("app", []string{"--config", path})
+	cmd.SetCollectsAllErrs(true)
+	err := cmd.ParseWith(optCfgs)
+
+	switch e := err.(type) {
+	case errors.ErrorList:
+		assert.Equal(t, len(e.Errs), 3)
+		assert.Equal(t, e.Errs[0], errors.ConfigValueIsInvalid{
+			Key: "foo", Option: "foo", StoreKey: "foo", Value: "1,2",
+			Cause: errors.OptionIsNotArray{Option: "foo", StoreKey: "foo"}})
+		assert.Equal(t, e.Errs[1].(errors.ConfigValueIsInvalid).Value, "x")
+		assert.Equal(t, e.Errs[1].(errors.ConfigValueIsInvalid).Cause.(errors.OptionArgIsInvalid).OptArg,
+			"x")
+		assert.Equal(t, e.Errs[2].(errors.ConfigValueIsInvalid).Value, "yes")
+		assert.Equal(t, errors.Message(e.Errs[2]),
+			"invalid value 'yes' of key 'baz' in configuration file for option '--baz': "+
+				"strconv.ParseBool: parsing \"yes\": invalid syntax")
+	default:
+		assert.Fail(t, err.Error())
+	}
+}
+
+func TestParseWith_configFileIsInvalid(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.yaml")
+	os.WriteFile(path, []byte("foo: 1\nbar\n"), 0644)
+
+	optCfgs := []cliargs.OptCfg{
+		cliargs.OptCfg{Names: []string{"config"}, HasArg: true, IsConfigFile: true},
+		cliargs.OptCfg{Names: []string{"foo"}, HasArg: true},
+	}
+
+	cmd := cliargs.NewCmdFromArgs("app", []string{"--config", path})
+	err := cmd.ParseWith(optCfgs)
+
+	switch e := err.(type) {
+	case errors.ConfigFileIsInvalid:
+		assert.Equal(t, e.File, path)
+		assert.Equal(t, e.Line, 2)
+	default:
+		assert.Fail(t, err.Error())
+	}
+	assert.False(t, cmd.HasOpt("foo"))
+}
+
+func TestParseWith_attachedShortOptArg(t *testing.T) {
+	optCfgs := []cliargs.OptCfg{
+		cliargs.OptCfg{Names: []string{"n"}, HasArg: true},
+		cliargs.OptCfg{Names: []string{"include", "I"}, HasArg: true, IsArray: true},
+		cliargs.OptCfg{Names: []string{"O"}, HasArg: true},
+		cliargs.OptCfg{Names: []string{"a"}},
+		cliargs.OptCfg{Names: []string{"b"}},
+	}
+
+	cmd := cliargs.NewCmdFromArgs("app", []string{"-n5", "-Iinclude", "-I", "lib", "-O2", "-abIsrc"})
+	err := cmd.ParseWith(optCfgs)
+
+	assert.Nil(t, err)
+	assert.Equal(t, cmd.Args, []string{})
+	assert.Equal(t, cmd.OptArg("n"), "5")
+	assert.Equal(t, cmd.OptArgs("include"), []string{"include", "lib", "src"})
+	assert.Equal(t, cmd.OptArg("O"), "2")
+	assert.True(t, cmd.HasOpt("a"))
+	assert.True(t, cmd.HasOpt("b"))
+}
+
+func TestParseWith_attachedShortOptArgContainingEqual(t *testing.T) {
+	optCfgs := []cliargs.OptCfg{
+		cliargs.OptCfg{Names: []string{"o"}, HasArg: true},
+		cliargs.OptCfg{Names: []string{"D"}, HasArg: true},
+	}
+
+	cmd := cliargs.NewCmdFromArgs("app", []string{"-o=foo", "-Dkey=value"})
+	err := cmd.ParseWith(optCfgs)
+
+	assert.Nil(t, err)
+	assert.Equal(t, cmd.OptArg("o"), "foo")
+	assert.Equal(t, cmd.OptArg("D"), "key=value")
+}
+
+func TestParseWith_combinedFlagsAreNotAttachedArg(t *testing.T) {
+	optCfgs := []cliargs.OptCfg{
+		cliargs.OptCfg{Names: []string{"a"}},
+		cliargs.OptCfg{Names: []string{"b"}},
+		cliargs.OptCfg{Names: []string{"c"}},
+	}
+
+	cmd := cliargs.NewCmdFromArgs("app", []string{"-abc"})
+	err := cmd.ParseWith(optCfgs)
+
+	assert.Nil(t, err)
+	assert.True(t, cmd.HasOpt("a"))
+	assert.True(t, cmd.HasOpt("b"))
+	assert.True(t, cmd.HasOpt("c"))
+
+	cmd = cliargs.NewCmdFromArgs("app", []string{"-abd"})
+	err = cmd.ParseWith(optCfgs)
+
+	switch e := err.(type) {
+	case errors.UnconfiguredOption:
+		assert.Equal(t, e.Option, "d")
+	default:
+		assert.Fail(t, err.Error())
+	}
+}
+
+func TestParseWith_attachedShortOptArgIsValidated(t *testing.T) {
+	optCfgs := []cliargs.OptCfg{
+		cliargs.OptCfg{Names: []string{"n"}, HasArg: true, Validator: &validators.ValidateInt},
+	}
+
+	cmd := cliargs.NewCmdFromArgs("app", []string{"-nx"})
+	err := cmd.ParseWith(optCfgs)
+
+	switch e := err.(type) {
+	case errors.OptionArgIsInvalid:
+		assert.Equal(t, e.Option, "n")
+		assert.Equal(t, e.OptArg, "x")
+	default:
+		assert.Fail(t, err.Error())
+	}
+}
+
+func TestParseWith_digitShortOpt(t *testing.T) {
+	optCfgs := []cliargs.OptCfg{
+		cliargs.OptCfg{StoreKey: "kill", Names: []string{"9"}},
+		cliargs.OptCfg{Names: []string{"v"}},
+	}
+
+	cmd := cliargs.NewCmdFromArgs("app", []string{"-9", "-v9", "1234"})
+	err := cmd.ParseWith(optCfgs)
+
+	assert.Nil(t, err)
+	assert.Equal(t, cmd.Args, []string{"1234"})
+	assert.True(t, cmd.HasOpt("kill"))
+	assert.True(t, cmd.HasOpt("v"))
+
+	cmd = cliargs.NewCmdFromArgs("app", []string{"-5"})
+	err = cmd.ParseWith(optCfgs)
+
+	switch e := err.(type) {
+	case errors.UnconfiguredOption:
+		assert.Equal(t, e.Option, "5")
+	default:
+		assert.Fail(t, err.Error())
+	}
+}
+
+func TestParseWith_digitShortOptIsNotConfigured(t *testing.T) {
+	optCfgs := []cliargs.OptCfg{
+		cliargs.OptCfg{Names: []string{"v"}},
+	}
+
+	cmd := cliargs.NewCmdFromArgs("app", []string{"-9"})
+	err := cmd.ParseWith(optCfgs)
+
+	switch e := err.(type) {
+	case errors.OptionContainsInvalidChar:
+		assert.Equal(t, e.Option, "9")
+	default:
+		assert.Fail(t, err.Error())
+	}
+}
+
+func TestParseWith_negativeNumberAsCmdArgWithDigitShortOpt(t *testing.T) {
+	optCfgs := []cliargs.OptCfg{
+		cliargs.OptCfg{StoreKey: "kill", Names: []string{"9"}},
+	}
+
+	cmd := cliargs.NewCmdFromArgs("app", []string{"-9", "-5"})
+	err := cmd.ParseWith(optCfgs)
+
+	switch e := err.(type) {
+	case errors.UnconfiguredOption:
+		assert.Equal(t, e.Option, "5")
+	default:
+		assert.Fail(t, err.Error())
+	}
+
+	cmd = cliargs.NewCmdFromArgs("app", []string{"-9", "-1.5"})
+	err = cmd.ParseWith(optCfgs)
+
+	switch e := err.(type) {
+	case errors.UnconfiguredOption:
+		assert.Equal(t, e.Option, "1")
+	default:
+		assert.Fail(t, err.Error())
+	}
+
+	cmd = cliargs.NewCmdFromArgs("app", []string{"-9", "--", "-5", "-1.5"})
+	err = cmd.ParseWith(optCfgs)
+
+	assert.Nil(t, err)
+	assert.True(t, cmd.HasOpt("kill"))
+	assert.Equal(t, cmd.Args, []string{"-5", "-1.5"})
+}
+
+func TestParseWith_negativeNumberAsOptArg(t *testing.T) {
+	optCfgs := []cliargs.OptCfg{
+		cliargs.OptCfg{Names: []string{"offset"}, HasArg: true, Validator: &validators.ValidateInt},
+		cliargs.OptCfg{Names: []string{"n"}, HasArg: true},
+		cliargs.OptCfg{Names: []string{"3"}},
+	}
+
+	cmd := cliargs.NewCmdFromArgs("app", []string{"--offset", "-3", "-n", "-1.5", "-3"})
+	err := cmd.ParseWith(optCfgs)
+
+	assert.Nil(t, err)
+	assert.Equal(t, cmd.Args, []string{})
+	assert.Equal(t, cmd.OptArg("offset"), "-3")
+	assert.Equal(t, cmd.OptArg("n"), "-1.5")
+	assert.True(t, cmd.HasOpt("3"))
+}
+
+func TestParseWith_unconfiguredOptionHasSuggestions(t *testing.T) {
+	optCfgs := []cliargs.OptCfg{
+		cliargs.OptCfg{Names: []string{"verbose", "v"}},
+		cliargs.OptCfg{Names: []string{"version"}},
+		cliargs.OptCfg{StoreKey: "dry-run"},
+	}
+
+	cmd := cliargs.NewCmdFromArgs("app", []string{"--verbsoe"})
+	err := cmd.ParseWith(optCfgs)
+
+	switch e := err.(type) {
+	case errors.UnconfiguredOption:
+		assert.Equal(t, e.Option, "verbsoe")
+		assert.Equal(t, e.Suggestions(), []string{"verbose"})
+	default:
+		assert.Fail(t, err.Error())
+	}
+	assert.True(t, goerrors.Is(err, errors.UnconfiguredOption{Option: "verbsoe"}))
+
+	cmd = cliargs.NewCmdFromArgs("app", []string{"--dryrun"})
+	err = cmd.ParseWith(optCfgs)
+
+	switch e := err.(type) {
+	case errors.UnconfiguredOption:
+		assert.Equal(t, e.Suggestions(), []string{"dry-run"})
+	default:
+		assert.Fail(t, err.Error())
+	}
+
+	cmd = cliargs.NewCmdFromArgs("app", []string{"-x"})
+	err = cmd.ParseWith(optCfgs)
+
+	switch e := err.(type) {
+	case errors.UnconfiguredOption:
+		assert.Equal(t, e.Option, "x")
+		assert.Nil(t, e.Suggestions())
+	default:
+		assert.Fail(t, err.Error())
+	}
+}
+
+func TestParseWith_requiredOptionIsGiven(t *testing.T) {
+	optCfgs := []cliargs.OptCfg{
+		cliargs.OptCfg{Names: []string{"foo", "f"}, HasArg: true, Required: true},
+		cliargs.OptCfg{Names: []string{"bar"}, Required: true},
+	}
+
+	cmd := cliargs.NewCmdFromArgs("app", []string{"-f", "1", "--bar"})
+	err := cmd.ParseWith(optCfgs)
+
+	assert.Nil(t, err)
+	assert.Equal(t, cmd.OptArg("foo"), "1")
+	assert.True(t, cmd.HasOpt("bar"))
+}
+
+func TestParseWith_requiredOptionsAreMissing(t *testing.T) {
+	optCfgs := []cliargs.OptCfg{
+		cliargs.OptCfg{StoreKey: "Foo", Names: []string{"foo", "f"}, HasArg: true, Required: true},
+		cliargs.OptCfg{Names: []string{"bar"}},
+		cliargs.OptCfg{StoreKey: "baz", Required: true},
+	}
+
+	cmd := cliargs.NewCmdFromArgs("app", []string{"--bar"})
+	err := cmd.ParseWith(optCfgs)
+
+	switch e := err.(type) {
+	case errors.MissingRequiredOption:
+		assert.Equal(t, e.Options, []string{"foo", "baz"})
+		assert.Equal(t, e.GetOption(), "foo")
+	default:
+		assert.Fail(t, err.Error())
+	}
+	assert.True(t, cmd.HasOpt("bar"))
+}
+
+func TestParseWith_requiredOptionIsFilledByEnvVarOrDefaults(t *testing.T) {
+	t.Setenv("APP_FOO", "1")
+
+	optCfgs := []cliargs.OptCfg{
+		cliargs.OptCfg{
+			Names: []string{"foo"}, HasArg: true, Required: true, EnvVars: []string{"APP_FOO"}},
+		cliargs.OptCfg{
+			Names: []string{"bar"}, HasArg: true, Required: true, Defaults: []string{"2"}},
+	}
+
+	cmd := cliargs.NewCmdFromArgs("app", []string{})
+	err := cmd.ParseWith(optCfgs)
+
+	assert.Nil(t, err)
+	assert.Equal(t, cmd.OptArg("foo"), "1")
+	assert.Equal(t, cmd.OptArg("bar"), "2")
+}
+
+func TestParseWith_requiredOptionIsMissingWithOtherErrors(t *testing.T) {
+	optCfgs := []cliargs.OptCfg{
+		cliargs.OptCfg{Names: []string{"foo"}, Required: true},
+	}
+
+	cmd := cliargs.NewCmdFromArgs("app", []string{"--bar"})
+	cmd.SetCollectsAllErrs(true)
+	err := cmd.ParseWith(optCfgs)
+
+	switch e := err.(type) {
+	case errors.ErrorList:
+		assert.Equal(t, len(e.Errs), 2)
+		assert.Equal(t, e.Errs[0], errors.UnconfiguredOption{Option: "bar"})
+		assert.Equal(t, e.Errs[1], errors.MissingRequiredOption{Options: []string{"foo"}})
+	default:
+		assert.Fail(t, err.Error())
+	}
+
+	var e0 errors.MissingRequiredOption
+	assert.True(t, goerrors.As(err, &e0))
+}
+
+func TestParseWith_exclusiveGroup(t *testing.T) {
+	optCfgs := []cliargs.OptCfg{
+		cliargs.OptCfg{Names: []string{"json"}, ExclusiveGroup: "format"},
+		cliargs.OptCfg{Names: []string{"yaml"}, ExclusiveGroup: "format"},
+		cliargs.OptCfg{Names: []string{"table", "t"}, ExclusiveGroup: "format"},
+	}
+
+	cmd := cliargs.NewCmdFromArgs("app", []string{"--yaml"})
+	err := cmd.ParseWith(optCfgs)
+	assert.Nil(t, err)
+	assert.True(t, cmd.HasOpt("yaml"))
+
+	cmd = cliargs.NewCmdFromArgs("app", []string{})
+	err = cmd.ParseWith(optCfgs)
+	assert.Nil(t, err)
+
+	cmd = cliargs.NewCmdFromArgs("app", []string{"--json", "-t"})
+	err = cmd.ParseWith(optCfgs)
+
+	switch e := err.(type) {
+	case errors.OptionsAreExclusive:
+		assert.Equal(t, e.Group, "format")
+		assert.Equal(t, e.Options, []string{"json", "table"})
+		assert.Equal(t, e.GetOption(), "json")
+	default:
+		assert.Fail(t, err.Error())
+	}
+}
+
+func TestParseWith_requiredExclusiveGroup(t *testing.T) {
+	optCfgs := []cliargs.OptCfg{
+		cliargs.OptCfg{Names: []string{"file"}, HasArg: true, ExclusiveGroup: "in", Required: true},
+		cliargs.OptCfg{Names: []string{"stdin"}, ExclusiveGroup: "in"},
+		cliargs.OptCfg{Names: []string{"verbose"}},
+	}
+
+	cmd := cliargs.NewCmdFromArgs("app", []string{"--stdin"})
+	err := cmd.ParseWith(optCfgs)
+	assert.Nil(t, err)
+
+	cmd = cliargs.NewCmdFromArgs("app", []string{"--verbose"})
+	err = cmd.ParseWith(optCfgs)
+
+	switch e := err.(type) {
+	case errors.OptionGroupIsMissing:
+		assert.Equal(t, e.Group, "in")
+		assert.Equal(t, e.Options, []string{"file", "stdin"})
+	default:
+		assert.Fail(t, err.Error())
+	}
+
+	cmd = cliargs.NewCmdFromArgs("app", []string{"--file", "a", "--stdin"})
+	err = cmd.ParseWith(optCfgs)
+
+	switch e := err.(type) {
+	case errors.OptionsAreExclusive:
+		assert.Equal(t, e.Options, []string{"file", "stdin"})
+	default:
+		assert.Fail(t, err.Error())
+	}
+}
+
+func TestParseWith_exclusiveGroupWithDefaults(t *testing.T) {
+	optCfgs := []cliargs.OptCfg{
+		cliargs.OptCfg{
+			Names: []string{"level"}, HasArg: true, Defaults: []string{"1"}, ExclusiveGroup: "g"},
+		cliargs.OptCfg{Names: []string{"quiet"}, ExclusiveGroup: "g", Required: true},
+	}
+
+	cmd := cliargs.NewCmdFromArgs("app", []string{"--quiet"})
+	err := cmd.ParseWith(optCfgs)
+	assert.Nil(t, err)
+	assert.Equal(t, cmd.OptArg("level"), "1")
+
+	cmd = cliargs.NewCmdFromArgs("app", []string{})
+	err = cmd.ParseWith(optCfgs)
+	assert.Nil(t, err)
+}
+
+func TestParseWith_requires(t *testing.T) {
+	optCfgs := []cliargs.OptCfg{
+		cliargs.OptCfg{
+			StoreKey: "User", Names: []string{"user", "u"}, HasArg: true,
+			Requires: []string{"password", "host"}},
+		cliargs.OptCfg{StoreKey: "Password", Names: []string{"password", "p"}, HasArg: true},
+		cliargs.OptCfg{Names: []string{"host"}, HasArg: true, Defaults: []string{"localhost"}},
+	}
+
+	cmd := cliargs.NewCmdFromArgs("app", []string{"-u", "me", "-p", "secret"})
+	err := cmd.ParseWith(optCfgs)
+	assert.Nil(t, err)
+
+	cmd = cliargs.NewCmdFromArgs("app", []string{"-p", "secret"})
+	err = cmd.ParseWith(optCfgs)
+	assert.Nil(t, err)
+
+	cmd = cliargs.NewCmdFromArgs("app", []string{"--user", "me"})
+	err = cmd.ParseWith(optCfgs)
+
+	switch e := err.(type) {
+	case errors.OptionRequiresOthers:
+		assert.Equal(t, e.Option, "user")
+		assert.Equal(t, e.StoreKey, "User")
+		assert.Equal(t, e.Missing, []string{"password"})
+	default:
+		assert.Fail(t, err.Error())
+	}
+}
+
+func TestParseWith_requiresIsNotTriggeredByDefaults(t *testing.T) {
+	optCfgs := []cliargs.OptCfg{
+		cliargs.OptCfg{
+			Names: []string{"user"}, HasArg: true, Defaults: []string{"guest"},
+			Requires: []string{"password"}},
+		cliargs.OptCfg{Names: []string{"password"}, HasArg: true},
+	}
+
+	cmd := cliargs.NewCmdFromArgs("app", []string{})
+	err := cmd.ParseWith(optCfgs)
+	assert.Nil(t, err)
+	assert.Equal(t, cmd.OptArg("user"), "guest")
+}
+
+func TestParseWith_helpIsRequested(t *testing.T) {
+	optCfgs := []cliargs.OptCfg{
+		cliargs.OptCfg{Names: []string{"help", "h"}, IsHelp: true},
+		cliargs.OptCfg{Names: []string{"version"}, IsVersion: true},
+		cliargs.OptCfg{Names: []string{"file"}, HasArg: true, Required: true},
+		cliargs.OptCfg{Names: []string{"num"}, HasArg: true, Validator: &validators.ValidateInt},
+	}
+
+	cmd := cliargs.NewCmdFromArgs("app", []string{"--num", "x", "-h", "--unknown"})
+	cmd.ArgCfgs = []cliargs.ArgCfg{cliargs.ArgCfg{Name: "src", Min: 1}}
+	err := cmd.ParseWith(optCfgs)
+
+	switch e := err.(type) {
+	case errors.HelpIsRequested:
+		assert.Equal(t, e.Option, "help")
+	default:
+		assert.Fail(t, err.Error())
+	}
+	assert.True(t, cmd.HasOpt("help"))
+
+	cmd = cliargs.NewCmdFromArgs("app", []string{"--version", "--help"})
+	err = cmd.ParseWith(optCfgs)
+	assert.Equal(t, err, errors.HelpIsRequested{Option: "help"})
+}
+
+func TestParseWith_versionIsRequested(t *testing.T) {
+	optCfgs := []cliargs.OptCfg{
+		cliargs.OptCfg{Names: []string{"help", "h"}, IsHelp: true},
+		cliargs.OptCfg{Names: []string{"version", "V"}, IsVersion: true},
+		cliargs.OptCfg{Names: []string{"file"}, HasArg: true, Required: true},
+	}
+
+	cmd := cliargs.NewCmdFromArgs("app", []string{"-V"})
+	cmd.SetCollectsAllErrs(true)
+	err := cmd.ParseWith(optCfgs)
+
+	var e errors.VersionIsRequested
+	assert.True(t, goerrors.As(err, &e))
+	assert.Equal(t, e.Option, "version")
+	assert.Equal(t, err, errors.ErrorList{Errs: []error{errors.VersionIsRequested{Option: "version"}}})
+}
+
+func TestParseWith_helpOptionIsNotGiven(t *testing.T) {
+	optCfgs := []cliargs.OptCfg{
+		cliargs.OptCfg{Names: []string{"help"}, IsHelp: true},
+		cliargs.OptCfg{Names: []string{"file"}, HasArg: true, Required: true},
+	}
+
+	cmd := cliargs.NewCmdFromArgs("app", []string{})
+	err := cmd.ParseWith(optCfgs)
+	assert.Equal(t, err, errors.MissingRequiredOption{Options: []string{"file"}})
+}
+
+func TestParseWith_mapOption(t *testing.T) {
+	optCfgs := []cliargs.OptCfg{
+		cliargs.OptCfg{Names: []string{"label", "l"}, HasArg: true, IsMap: true},
+	}
+
+	cmd := cliargs.NewCmdFromArgs("app", []string{
+		"--label", "env=prod", "-l", "tier=web", "--label=env=dev", "--label", "note="})
+	err := cmd.ParseWith(optCfgs)
+
+	assert.Nil(t, err)
+	assert.Equal(t, cmd.OptArgs("label"), []string{"env=prod", "tier=web", "env=dev", "note="})
+	assert.Equal(t, cmd.OptMap("label"),
+		map[string]string{"env": "dev", "tier": "web", "note": ""})
+}
+
+func TestParseWith_mapOptionArgIsNotKeyValue(t *testing.T) {
+	optCfgs := []cliargs.OptCfg{
+		cliargs.OptCfg{Names: []string{"label"}, HasArg: true, IsMap: true},
+	}
+
+	cmd := cliargs.NewCmdFromArgs("app", []string{"--label", "env"})
+	err := cmd.ParseWith(optCfgs)
+
+	switch e := err.(type) {
+	case errors.OptionArgIsInvalid:
+		assert.Equal(t, e.StoreKey, "label")
+		assert.Equal(t, e.Option, "label")
+		assert.Equal(t, e.OptArg, "env")
+		assert.Equal(t, e.Cause, errors.OptArgIsNotKeyValue{OptArg: "env"})
+	default:
+		assert.Fail(t, err.Error())
+	}
+}
+
+func TestParseWith_mapOptionRejectsDupKey(t *testing.T) {
+	optCfgs := []cliargs.OptCfg{
+		cliargs.OptCfg{Names: []string{"label", "l"}, HasArg: true, IsMap: true, RejectsDupKey: true},
+	}
+
+	cmd := cliargs.NewCmdFromArgs("app", []string{"--label", "env=prod", "-l", "tier=web"})
+	err := cmd.ParseWith(optCfgs)
+	assert.Nil(t, err)
+	assert.Equal(t, cmd.OptMap("label"), map[string]string{"env": "prod", "tier": "web"})
+
+	cmd = cliargs.NewCmdFromArgs("app", []string{"--label", "env=prod", "-l", "env=dev"})
+	err = cmd.ParseWith(optCfgs)
+	assert.Equal(t, err, errors.MapKeyIsDuplicated{StoreKey: "label", Option: "l", Key: "env"})
+}
+
+func TestParseWith_mapOptionValidatesValues(t *testing.T) {
+	optCfgs := []cliargs.OptCfg{
+		cliargs.OptCfg{
+			Names: []string{"limit"}, HasArg: true, IsMap: true, Validator: &validators.ValidateInt},
+	}
+
+	cmd := cliargs.NewCmdFromArgs("app", []string{"--limit", "cpu=2", "--limit", "mem=x"})
+	err := cmd.ParseWith(optCfgs)
+
+	switch e := err.(type) {
+	case errors.OptionArgIsInvalid:
+		assert.Equal(t, e.Option, "limit")
+		assert.Equal(t, e.OptArg, "x")
+	default:
+		assert.Fail(t, err.Error())
+	}
+}
+
+func TestParseWith_mapOptionFromEnvVar(t *testing.T) {
+	t.Setenv("APP_LABELS", "env=prod,tier=web")
+
+	optCfgs := []cliargs.OptCfg{
+		cliargs.OptCfg{
+			Names: []string{"label"}, HasArg: true, IsMap: true, EnvVars: []string{"APP_LABELS"}},
+	}
+
+	cmd := cliargs.NewCmdFromArgs("app", []string{})
+	err := cmd.ParseWith(optCfgs)
+	assert.Nil(t, err)
+	assert.Equal(t, cmd.OptMap("label"), map[string]string{"env": "prod", "tier": "web"})
+
+	t.Setenv("APP_LABELS", "env=prod,tier")
+	cmd = cliargs.NewCmdFromArgs("app", []string{})
+	err = cmd.ParseWith(optCfgs)
+
+	switch e := err.(type) {
+	case errors.EnvVarIsInvalid:
+		assert.Equal(t, e.EnvVar, "APP_LABELS")
+		assert.Equal(t, e.Option, "label")
+		assert.Equal(t, e.Cause.(errors.OptionArgIsInvalid).OptArg, "tier")
+	default:
+		assert.Fail(t, err.Error())
+	}
+}
+
+func TestParseWith_mapOptionFromConfigFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.json")
+	os.WriteFile(path, []byte(`{"label": {"env": "prod", "tier": "web"}, "tag": ["a=1"]}`), 0644)
+
+	optCfgs := []cliargs.OptCfg{
+		cliargs.OptCfg{Names: []string{"config"}, HasArg: true, IsConfigFile: true},
+		cliargs.OptCfg{Names: []string{"label"}, HasArg: true, IsMap: true},
+		cliargs.OptCfg{Names: []string{"tag"}, HasArg: true, IsMap: true},
+	}
+
+	cmd := cliargs.NewCmdFromArgs("app", []string{"--config", path})
+	err := cmd.ParseWith(optCfgs)
+
+	assert.Nil(t, err)
+	assert.Equal(t, cmd.OptArgs("label"), []string{"env=prod", "tier=web"})
+	assert.Equal(t, cmd.OptMap("tag"), map[string]string{"a": "1"})
+}
+
+func TestParseWith_mapOptionHasNoArg(t *testing.T) {
+	optCfgs := []cliargs.OptCfg{
+		cliargs.OptCfg{Names: []string{"label"}, IsMap: true},
+	}
+
+	cmd := cliargs.NewCmdFromArgs("app", []string{})
+	err := cmd.ParseWith(optCfgs)
+	assert.Equal(t, err, errors.ConfigIsMapButHasNoArg{StoreKey: "label", Name: "label"})
+}
@@ -19,6 +19,13 @@ var (
 			{0x0061, 0x007a, 1}, // a-z
 		},
 	}
+	rangeOfAlNums = &unicode.RangeTable{
+		R16: []unicode.Range16{
+			{0x0030, 0x0039, 1}, // 0-9
+			{0x0041, 0x005a, 1}, // A-Z
+			{0x0061, 0x007a, 1}, // a-z
+		},
+	}
 	rangeOfAlNumMarks = &unicode.RangeTable{
 		R16: []unicode.Range16{
 			{0x002d, 0x002d, 1}, // -
@@ -60,8 +67,9 @@ func (cmd *Cmd) Parse() error {
 		return nil
 	}
 
-	_, _, err := parseArgs(cmd._args, collectArgs, collectOpts, takeOptArgs, false, cmd.isAfterEndOpt)
-	return err
+	_, _, errs := parseArgs(
+		cmd._args, collectArgs, collectOpts, takeOptArgs, false, false, cmd.isAfterEndOpt)
+	return cmd.toErr(errs)
 }
 
 // ParseUntilSubCmd is the method that parses command line arguments without configurations but
@@ -84,8 +92,9 @@ func (cmd *Cmd) ParseUntilSubCmd() (Cmd, error) {
 		return nil
 	}
 
-	idx, isAfterEndOpt, err := parseArgs(
-		cmd._args, collectArgs, collectOpts, takeOptArgs, true, cmd.isAfterEndOpt)
+	idx, isAfterEndOpt, errs := parseArgs(
+		cmd._args, collectArgs, collectOpts, takeOptArgs, false, true, cmd.isAfterEndOpt)
+	err := cmd.toErr(errs)
 	if idx < 0 {
 		return Cmd{}, err
 	}
@@ -101,18 +110,24 @@ func parseArgs(
 	collectArgs func(string),
 	collectOpts func(string, ...string) error,
 	takeOptArgs func(string) bool,
+	allowsDigitShortOpt bool,
 	untilFirstArg bool,
 	isAfterEndOpt bool,
-) (int, bool, error) {
+) (int, bool, []error) {
+
+	rangeOfShortOpt := rangeOfAlphabets
+	if allowsDigitShortOpt {
+		rangeOfShortOpt = rangeOfAlNums
+	}
 
 	prevOptTakingArgs := ""
-	var firstErr error = nil
+	var errs []error
 
 L0:
 	for iArg, arg := range osArgs {
 		if isAfterEndOpt {
 			if untilFirstArg {
-				return iArg, isAfterEndOpt, firstErr
+				return iArg, isAfterEndOpt, errs
 			}
 			collectArgs(arg)
 
@@ -120,9 +135,7 @@ L0:
 			err := collectOpts(prevOptTakingArgs, arg)
 			prevOptTakingArgs = ""
 			if err != nil {
-				if firstErr == nil {
-					firstErr = err
-				}
+				errs = append(errs, err)
 				continue L0
 			}
 		} else if strings.HasPrefix(arg, "--") {
@@ -139,24 +152,18 @@ L0:
 						rr := []rune(arg)
 						err := collectOpts(string(rr[0:i]), string(rr[i+1:]))
 						if err != nil {
-							if firstErr == nil {
-								firstErr = err
-							}
+							errs = append(errs, err)
 							continue L0
 						}
 						break
 					}
 					if !unicode.Is(rangeOfAlNumMarks, r) {
-						if firstErr == nil {
-							firstErr = errors.OptionContainsInvalidChar{Option: arg}
-						}
+						errs = append(errs, errors.OptionContainsInvalidChar{Option: arg})
 						continue L0
 					}
 				} else {
 					if !unicode.Is(rangeOfAlphabets, r) {
-						if firstErr == nil {
-							firstErr = errors.OptionContainsInvalidChar{Option: arg}
-						}
+						errs = append(errs, errors.OptionContainsInvalidChar{Option: arg})
 						continue L0
 					}
 				}
@@ -170,9 +177,7 @@ L0:
 				}
 				err := collectOpts(arg)
 				if err != nil {
-					if firstErr == nil {
-						firstErr = err
-					}
+					errs = append(errs, err)
 					continue L0
 				}
 			}
@@ -180,7 +185,7 @@ L0:
 		} else if strings.HasPrefix(arg, "-") {
 			if len(arg) == 1 {
 				if untilFirstArg {
-					return iArg, isAfterEndOpt, firstErr
+					return iArg, isAfterEndOpt, errs
 				}
 				collectArgs(arg)
 				continue L0
@@ -196,26 +201,28 @@ L0:
 							rr := []rune(arg)
 							err := collectOpts(name, string(rr[i+1:]))
 							if err != nil {
-								if firstErr == nil {
-									firstErr = err
-								}
+								errs = append(errs, err)
 							}
 						}
 						continue L0
 					}
+					if len(name) > 0 && takeOptArgs(name) {
+						rr := []rune(arg)
+						err := collectOpts(name, string(rr[i:]))
+						if err != nil {
+							errs = append(errs, err)
+						}
+						continue L0
+					}
 					if len(name) > 0 {
 						err := collectOpts(name)
 						if err != nil {
-							if firstErr == nil {
-								firstErr = err
-							}
+							errs = append(errs, err)
 						}
 					}
 				}
-				if !unicode.Is(rangeOfAlphabets, r) {
-					if firstErr == nil {
-						firstErr = errors.OptionContainsInvalidChar{Option: string(r)}
-					}
+				if !unicode.Is(rangeOfShortOpt, r) {
+					errs = append(errs, errors.OptionContainsInvalidChar{Option: string(r)})
 					name = ""
 				} else {
 					name = string(r)
@@ -229,9 +236,7 @@ L0:
 				} else {
 					err := collectOpts(name)
 					if err != nil {
-						if firstErr == nil {
-							firstErr = err
-						}
+						errs = append(errs, err)
 						continue L0
 					}
 				}
@@ -239,11 +244,11 @@ L0:
 
 		} else {
 			if untilFirstArg {
-				return iArg, isAfterEndOpt, firstErr
+				return iArg, isAfterEndOpt, errs
 			}
 			collectArgs(arg)
 		}
 	}
 
-	return -1, isAfterEndOpt, firstErr
+	return -1, isAfterEndOpt, errs
 }
@@ -910,3 +910,98 @@ func TestParseUntilSubCmd_afterEndOptMark(t *testing.T) {
 	assert.Equal(t, subCmd.OptArg("baz"), "")
 	assert.Equal(t, subCmd.OptArgs("baz"), []string(nil))
 }
+
+func TestParse_withCmdFromArgs(t *testing.T) {
+	cmd := cliargs.NewCmdFromArgs("app", []string{"--foo-bar", "qux", "-a=1", "-b"})
+	err := cmd.Parse()
+
+	assert.Nil(t, err)
+	assert.Equal(t, cmd.Name, "app")
+	assert.Equal(t, cmd.Args, []string{"qux"})
+	assert.True(t, cmd.HasOpt("foo-bar"))
+	assert.Equal(t, cmd.OptArgs("a"), []string{"1"})
+	assert.True(t, cmd.HasOpt("b"))
+}
+
+func TestParseUntilSubCmd_withCmdFromArgs(t *testing.T) {
+	cmd := cliargs.NewCmdFromArgs("app", []string{"--foo-bar", "qux", "-a=1", "quux"})
+	subCmd, err := cmd.ParseUntilSubCmd()
+
+	assert.Nil(t, err)
+	assert.Equal(t, cmd.Name, "app")
+	assert.Equal(t, cmd.Args, []string{})
+	assert.True(t, cmd.HasOpt("foo-bar"))
+
+	err = subCmd.Parse()
+	assert.Nil(t, err)
+	assert.Equal(t, subCmd.Name, "qux")
+	assert.Equal(t, subCmd.Args, []string{"quux"})
+	assert.Equal(t, subCmd.OptArgs("a"), []string{"1"})
+}
+
+func TestParse_resetArgsAndParseAgain(t *testing.T) {
+	cmd := cliargs.NewCmdFromArgs("app", []string{"--foo-bar", "qux"})
+	err := cmd.Parse()
+
+	assert.Nil(t, err)
+	assert.Equal(t, cmd.Args, []string{"qux"})
+	assert.True(t, cmd.HasOpt("foo-bar"))
+
+	cmd.ResetArgs([]string{"-a", "quux", "corge"})
+	err = cmd.Parse()
+
+	assert.Nil(t, err)
+	assert.Equal(t, cmd.Name, "app")
+	assert.Equal(t, cmd.Args, []string{"quux", "corge"})
+	assert.False(t, cmd.HasOpt("foo-bar"))
+	assert.True(t, cmd.HasOpt("a"))
+}
+
+func TestParse_collectAllErrs(t *testing.T) {
+	cmd := cliargs.NewCmdFromArgs("app", []string{"-a1", "--b@z", "qux", "-9"})
+	cmd.SetCollectsAllErrs(true)
+	err := cmd.Parse()
+
+	assert.Equal(t, err, errors.ErrorList{Errs: []error{
+		errors.OptionContainsInvalidChar{Option: "1"},
+		errors.OptionContainsInvalidChar{Option: "b@z"},
+		errors.OptionContainsInvalidChar{Option: "9"},
+	}})
+	assert.Equal(t, cmd.Args, []string{"qux"})
+	assert.True(t, cmd.HasOpt("a"))
+}
+
+func TestParse_returnFirstErrIfNotCollectAllErrs(t *testing.T) {
+	cmd := cliargs.NewCmdFromArgs("app", []string{"-a1", "--b@z", "qux", "-9"})
+	err := cmd.Parse()
+
+	assert.Equal(t, err, errors.OptionContainsInvalidChar{Option: "1"})
+}
+
+func TestParseUntilSubCmd_collectAllErrs(t *testing.T) {
+	cmd := cliargs.NewCmdFromArgs("app", []string{"-1", "-2", "qux", "-3", "-4"})
+	cmd.SetCollectsAllErrs(true)
+	subCmd, err := cmd.ParseUntilSubCmd()
+
+	assert.Equal(t, err, errors.ErrorList{Errs: []error{
+		errors.OptionContainsInvalidChar{Option: "1"},
+		errors.OptionContainsInvalidChar{Option: "2"},
+	}})
+
+	err = subCmd.Parse()
+	assert.Equal(t, err, errors.ErrorList{Errs: []error{
+		errors.OptionContainsInvalidChar{Option: "3"},
+		errors.OptionContainsInvalidChar{Option: "4"},
+	}})
+}
+
+func TestParse_attachedShortOptArgIsNotSupported(t *testing.T) {
+	cmd := cliargs.NewCmdFromArgs("app", []string{"-ofoo"})
+	err := cmd.Parse()
+
+	assert.Nil(t, err)
+	assert.Equal(t, cmd.Args, []string{})
+	assert.True(t, cmd.HasOpt("o"))
+	assert.True(t, cmd.HasOpt("f"))
+	assert.Equal(t, cmd.OptArg("o"), "")
+}
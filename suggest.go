@@ -0,0 +1,107 @@
+// Copyright (C) 2026 Takayuki Sato. All Rights Reserved.
+// This program is free software under MIT License.
+// See the file LICENSE in this distribution for more details.
+
+package cliargs
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/sttk/cliargs/errors"
+)
+
+// Suggest is the function that returns the candidates which are similar to the input, for
+// example, to show "did you mean" suggestions for a mistyped option name or sub command name.
+//
+// A candidate is regarded as similar if it starts with the input, or the edit distance
+// (Levenshtein distance) between them is small enough for the length of the input.
+// The returned candidates are ordered by the edit distance, and candidates with the same distance
+// keep their order in the argument.
+// Single character inputs and candidates, like short option names, are not compared.
+func Suggest(input string, candidates []string) []string {
+	in := []rune(input)
+	if len(in) < 2 {
+		return nil
+	}
+
+	limit := 1
+	if len(in) >= 4 {
+		limit = 2
+	}
+
+	type suggestion struct {
+		name string
+		dist int
+	}
+	var found []suggestion
+	seen := make(map[string]struct{})
+
+	for _, c := range candidates {
+		if _, exists := seen[c]; exists {
+			continue
+		}
+		seen[c] = struct{}{}
+
+		if c == input || len([]rune(c)) < 2 {
+			continue
+		}
+
+		d := editDistance(in, []rune(c))
+		if d <= limit || strings.HasPrefix(c, input) {
+			found = append(found, suggestion{name: c, dist: d})
+		}
+	}
+
+	sort.SliceStable(found, func(i, j int) bool {
+		return found[i].dist < found[j].dist
+	})
+
+	var names []string
+	for _, s := range found {
+		names = append(names, s.name)
+	}
+	return names
+}
+
+// UnconfiguredSubCmdOf is the function that creates an UnconfiguredSubCmd error for the input sub
+// command name, which has the names similar to it among the configured sub command names as its
+// suggestions.
+//
+// This function is for the sub command returned by Cmd#ParseUntilSubCmdWith or
+// Cmd#ParseUntilSubCmdFor, of which name matches none of the sub commands supported by the caller.
+func UnconfiguredSubCmdOf(name string, subCmdNames []string) errors.UnconfiguredSubCmd {
+	return errors.NewUnconfiguredSubCmd(name, Suggest(name, subCmdNames))
+}
+
+func editDistance(a, b []rune) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = minOf3(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+
+	return prev[len(b)]
+}
+
+func minOf3(a, b, c int) int {
+	if b < a {
+		a = b
+	}
+	if c < a {
+		a = c
+	}
+	return a
+}
@@ -0,0 +1,62 @@
+package cliargs_test
+
+import (
+	goerrors "errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sttk/cliargs"
+	"github.com/sttk/cliargs/errors"
+)
+
+func TestSuggest(t *testing.T) {
+	candidates := []string{"verbose", "version", "v", "config", "color", "dry-run"}
+
+	assert.Equal(t, cliargs.Suggest("verbsoe", candidates), []string{"verbose"})
+	assert.Equal(t, cliargs.Suggest("versio", candidates), []string{"version"})
+	assert.Equal(t, cliargs.Suggest("ver", candidates), []string{"verbose", "version"})
+	assert.Equal(t, cliargs.Suggest("colr", candidates), []string{"color"})
+	assert.Equal(t, cliargs.Suggest("dryrun", candidates), []string{"dry-run"})
+	assert.Equal(t, cliargs.Suggest("quux", candidates), []string(nil))
+}
+
+func TestSuggest_shortInput(t *testing.T) {
+	candidates := []string{"verbose", "v", "x", "xy"}
+
+	assert.Equal(t, cliargs.Suggest("x", candidates), []string(nil))
+	assert.Equal(t, cliargs.Suggest("", candidates), []string(nil))
+	assert.Equal(t, cliargs.Suggest("xz", candidates), []string{"xy"})
+}
+
+func TestSuggest_excludesSameAndDuplicated(t *testing.T) {
+	candidates := []string{"remote", "remote", "remove", "rm"}
+
+	assert.Equal(t, cliargs.Suggest("remote", candidates), []string{"remove"})
+	assert.Equal(t, cliargs.Suggest("remot", candidates), []string{"remote", "remove"})
+}
+
+func TestSuggest_multiByteChars(t *testing.T) {
+	assert.Equal(t, cliargs.Suggest("ぱらめた", []string{"ぱらめーた", "ぴ"}), []string{"ぱらめーた"})
+}
+
+func TestUnconfiguredSubCmdOf_afterParseUntilSubCmdWith(t *testing.T) {
+	subCmdNames := []string{"status", "stash", "remove"}
+
+	cmd := cliargs.NewCmdFromArgs("app", []string{"-v", "stat", "-a"})
+	subCmd, err := cmd.ParseUntilSubCmdWith([]cliargs.OptCfg{
+		cliargs.OptCfg{Names: []string{"v"}},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, subCmd.Name, "stat")
+
+	e := cliargs.UnconfiguredSubCmdOf(subCmd.Name, subCmdNames)
+	assert.Equal(t, e.Name, "stat")
+	assert.Equal(t, e.Suggestions(), []string{"status", "stash"})
+	assert.Equal(t, errors.Message(e), "unknown command 'stat' (did you mean 'status', 'stash'?)")
+	assert.True(t, goerrors.Is(e, errors.UnconfiguredSubCmd{Name: "stat"}))
+
+	e = cliargs.UnconfiguredSubCmdOf("quux", subCmdNames)
+	assert.Equal(t, e, errors.UnconfiguredSubCmd{Name: "quux"})
+	assert.Nil(t, e.Suggestions())
+}
@@ -0,0 +1,112 @@
+// Copyright (C) 2026 Takayuki Sato. All Rights Reserved.
+// This program is free software under MIT License.
+// See the file LICENSE in this distribution for more details.
+
+package cliargs
+
+import (
+	"encoding"
+	"reflect"
+
+	"github.com/sttk/cliargs/errors"
+)
+
+// Value is the interface for the types of fields of an option store which convert option arguments
+// to their values by themselves.
+//
+// Set is the method which is called with an option argument by ParseFor, and its error is
+// returned as the Cause of OptionArgIsInvalid error.
+// String is the method which returns the string representation of the value.
+// If a field of which type implements this interface has a string representation before parsing
+// which differs from that of the zero value of the type, and no default value is specified in its
+// struct tag, the string is shown as the default value of the option in a help text, but it is not
+// used as an option argument.
+type Value interface {
+	Set(string) error
+	String() string
+}
+
+var (
+	valueType           = reflect.TypeOf((*Value)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// isSelfSettableType is the function that checks whether the pointer type of the specified type
+// implements Value or encoding.TextUnmarshaler.
+func isSelfSettableType(t reflect.Type) bool {
+	pt := reflect.PointerTo(t)
+	return pt.Implements(valueType) || pt.Implements(textUnmarshalerType)
+}
+
+func setSelf(ptr reflect.Value, s string) error {
+	if v, ok := ptr.Interface().(Value); ok {
+		return v.Set(s)
+	}
+	return ptr.Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(s))
+}
+
+// defaultInHelpOf is the function that returns the string representation of the field as a
+// display of the default value in a help text if the type of the field implements Value.
+// If the string representation is same as that of the zero value of the type, like an empty
+// string or a placeholder of an unset value, this function returns an empty string.
+func defaultInHelpOf(fld reflect.Value) string {
+	if !fld.CanAddr() {
+		return ""
+	}
+	v, ok := fld.Addr().Interface().(Value)
+	if !ok {
+		return ""
+	}
+	s := v.String()
+	if s == reflect.New(fld.Type()).Interface().(Value).String() {
+		return ""
+	}
+	return s
+}
+
+func newSelfSetter(
+	optName string, fldName string, fld reflect.Value,
+) (func([]string) error, error) {
+	fn := func(s []string) error {
+		if len(s) == 0 {
+			return nil
+		}
+		e := setSelf(fld.Addr(), s[0])
+		if e != nil {
+			return errors.OptionArgIsInvalid{
+				Option: optName, StoreKey: fldName, OptArg: s[0], TypeKind: fld.Type().Kind(), Cause: e}
+		}
+		return nil
+	}
+	return fn, nil
+}
+
+func newSelfArraySetter(
+	optName string, fldName string, fld reflect.Value,
+) (func([]string) error, error) {
+	t := fld.Type().Elem()
+	fn := func(s []string) error {
+		if s == nil {
+			return nil
+		}
+		emp := reflect.MakeSlice(fld.Type(), 0, 0)
+		n := len(s)
+		if n == 0 {
+			fld.Set(emp)
+			return nil
+		}
+		a := make([]reflect.Value, n)
+		for i := 0; i < n; i++ {
+			ptr := reflect.New(t)
+			e := setSelf(ptr, s[i])
+			if e != nil {
+				return errors.OptionArgIsInvalid{
+					Option: optName, StoreKey: fldName, OptArg: s[i], TypeKind: t.Kind(), Cause: e}
+			}
+			a[i] = ptr.Elem()
+		}
+		fld.Set(reflect.Append(emp, a...))
+		return nil
+	}
+	return fn, nil
+}
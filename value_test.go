@@ -0,0 +1,233 @@
+package cliargs_test
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sttk/cliargs"
+	"github.com/sttk/cliargs/errors"
+)
+
+type logLevel int
+
+const (
+	levelInfo logLevel = iota
+	levelDebug
+	levelError
+)
+
+var logLevelNames = []string{"info", "debug", "error"}
+
+func (l *logLevel) Set(s string) error {
+	for i, nm := range logLevelNames {
+		if nm == s {
+			*l = logLevel(i)
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown log level: %s", s)
+}
+
+func (l logLevel) String() string {
+	return logLevelNames[l]
+}
+
+type userID struct {
+	num int
+}
+
+func (id *userID) UnmarshalText(text []byte) error {
+	s := string(text)
+	if !strings.HasPrefix(s, "u") {
+		return fmt.Errorf("bad user id: %s", s)
+	}
+	_, e := fmt.Sscanf(s[1:], "%d", &id.num)
+	return e
+}
+
+type unsetMode string
+
+func (m *unsetMode) Set(s string) error {
+	if s != "fast" && s != "slow" {
+		return fmt.Errorf("unknown mode: %s", s)
+	}
+	*m = unsetMode(s)
+	return nil
+}
+
+func (m unsetMode) String() string {
+	if len(m) == 0 {
+		return "<unset>"
+	}
+	return string(m)
+}
+
+type tags []string
+
+func (t *tags) Set(s string) error {
+	*t = strings.Split(s, ":")
+	return nil
+}
+
+func (t tags) String() string {
+	return strings.Join(t, ":")
+}
+
+func TestParseFor_value(t *testing.T) {
+	type MyOptions struct {
+		Level  logLevel   `optcfg:"level"`
+		User   userID     `optcfg:"user"`
+		Users  []userID   `optcfg:"users"`
+		Levels []logLevel `optcfg:"levels"`
+		Tags   tags       `optcfg:"tags"`
+	}
+	options := MyOptions{}
+
+	cmd := cliargs.NewCmdFromArgs("app", []string{
+		"--level", "debug", "--user", "u12", "--users", "u1", "--users", "u2",
+		"--levels", "error", "--levels", "info", "--tags", "a:b"})
+	err := cmd.ParseFor(&options)
+
+	assert.Nil(t, err)
+	assert.Equal(t, options.Level, levelDebug)
+	assert.Equal(t, options.User, userID{num: 12})
+	assert.Equal(t, options.Users, []userID{userID{num: 1}, userID{num: 2}})
+	assert.Equal(t, options.Levels, []logLevel{levelError, levelInfo})
+	assert.Equal(t, options.Tags, tags{"a", "b"})
+
+	assert.True(t, cmd.OptCfgs[0].HasArg)
+	assert.False(t, cmd.OptCfgs[0].IsArray)
+	assert.True(t, cmd.OptCfgs[2].IsArray)
+	assert.False(t, cmd.OptCfgs[4].IsArray)
+}
+
+func TestParseFor_value_defaultInHelpFromString(t *testing.T) {
+	type MyOptions struct {
+		Level logLevel `optcfg:"level" optdesc:"Log level"`
+		Other logLevel `optcfg:"other=error"`
+		Tags  tags     `optcfg:"tags"`
+	}
+	options := MyOptions{Level: levelDebug, Other: levelDebug}
+
+	cmd := cliargs.NewCmdFromArgs("app", []string{})
+	err := cmd.ParseFor(&options)
+
+	assert.Nil(t, err)
+	assert.Nil(t, cmd.OptCfgs[0].Defaults)
+	assert.Equal(t, cmd.OptCfgs[0].DefaultInHelp, "debug")
+	assert.Equal(t, cmd.OptCfgs[1].Defaults, []string{"error"})
+	assert.Equal(t, cmd.OptCfgs[1].DefaultInHelp, "")
+	assert.Nil(t, cmd.OptCfgs[2].Defaults)
+	assert.Equal(t, cmd.OptCfgs[2].DefaultInHelp, "")
+	assert.Equal(t, options.Level, levelDebug)
+	assert.Equal(t, options.Other, levelError)
+	assert.False(t, cmd.HasOpt("Level"))
+
+	help := cliargs.NewHelp()
+	help.AddOpts(cmd.OptCfgs[0:1])
+	iter := help.Iter()
+	line, _ := iter.Next()
+	assert.Equal(t, line, "--level  Log level (default: debug)")
+}
+
+func TestParseFor_value_defaultInHelpIsNotZeroValue(t *testing.T) {
+	type MyOptions struct {
+		Level logLevel  `optcfg:"level" optdesc:"Log level"`
+		Mode  unsetMode `optcfg:"mode" optdesc:"Mode"`
+	}
+	options := MyOptions{Level: levelInfo}
+
+	cmd := cliargs.NewCmdFromArgs("app", []string{})
+	err := cmd.ParseFor(&options)
+
+	assert.Nil(t, err)
+	assert.Equal(t, cmd.OptCfgs[0].DefaultInHelp, "")
+	assert.Equal(t, cmd.OptCfgs[1].DefaultInHelp, "")
+
+	options = MyOptions{Level: levelError, Mode: unsetMode("fast")}
+	cmd = cliargs.NewCmdFromArgs("app", []string{})
+	err = cmd.ParseFor(&options)
+
+	assert.Nil(t, err)
+	assert.Equal(t, cmd.OptCfgs[0].DefaultInHelp, "error")
+	assert.Equal(t, cmd.OptCfgs[1].DefaultInHelp, "fast")
+
+	help := cliargs.NewHelp()
+	help.AddOpts(cmd.OptCfgs)
+	iter := help.Iter()
+	line, _ := iter.Next()
+	assert.Equal(t, line, "--level  Log level (default: error)")
+	line, _ = iter.Next()
+	assert.Equal(t, line, "--mode   Mode (default: fast)")
+}
+
+func TestParseFor_value_stringIsNotUsedAsOptArg(t *testing.T) {
+	type MyOptions struct {
+		Level logLevel  `optcfg:"level" optreq:"true"`
+		Mode  unsetMode `optcfg:"mode"`
+	}
+	options := MyOptions{}
+
+	cmd := cliargs.NewCmdFromArgs("app", []string{})
+	err := cmd.ParseFor(&options)
+
+	assert.Equal(t, err, errors.MissingRequiredOption{Options: []string{"level"}})
+	assert.False(t, cmd.HasOpt("Level"))
+	assert.False(t, cmd.HasOpt("Mode"))
+	assert.Equal(t, cmd.OptCfgs[1].DefaultInHelp, "")
+	assert.Equal(t, options.Mode, unsetMode(""))
+}
+
+func TestParseFor_value_invalid(t *testing.T) {
+	type MyOptions struct {
+		Level logLevel `optcfg:"level"`
+		Users []userID `optcfg:"users"`
+	}
+	options := MyOptions{}
+
+	cmd := cliargs.NewCmdFromArgs("app", []string{"--level", "trace"})
+	err := cmd.ParseFor(&options)
+
+	switch e := err.(type) {
+	case errors.OptionArgIsInvalid:
+		assert.Equal(t, e.Option, "level")
+		assert.Equal(t, e.StoreKey, "Level")
+		assert.Equal(t, e.OptArg, "trace")
+		assert.Equal(t, e.TypeKind, reflect.Int)
+		assert.Equal(t, e.Cause.Error(), "unknown log level: trace")
+	default:
+		assert.Fail(t, err.Error())
+	}
+
+	options = MyOptions{}
+	cmd = cliargs.NewCmdFromArgs("app", []string{"--users", "u1", "--users", "x"})
+	err = cmd.ParseFor(&options)
+
+	switch e := err.(type) {
+	case errors.OptionArgIsInvalid:
+		assert.Equal(t, e.OptArg, "x")
+		assert.Equal(t, e.TypeKind, reflect.Struct)
+		assert.Equal(t, e.Cause.Error(), "bad user id: x")
+	default:
+		assert.Fail(t, err.Error())
+	}
+}
+
+func TestParseFor_value_cmdArgs(t *testing.T) {
+	type MyOptions struct {
+		Level logLevel `optpos:"0"`
+		Users []userID `optpos:"rest"`
+	}
+	options := MyOptions{}
+
+	cmd := cliargs.NewCmdFromArgs("app", []string{"error", "u3", "u4"})
+	err := cmd.ParseFor(&options)
+
+	assert.Nil(t, err)
+	assert.Equal(t, options.Level, levelError)
+	assert.Equal(t, options.Users, []userID{userID{num: 3}, userID{num: 4}})
+}